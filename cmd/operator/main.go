@@ -7,15 +7,13 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
-	"strings"
 	"syscall"
+	"time"
 
-	"github.com/go-kit/kit/log"
-	"github.com/go-kit/kit/log/level"
 	"github.com/oklog/run"
-	"github.com/pkg/errors"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
+	logsapiv1 "k8s.io/component-base/logs/api/v1"
 	klogv1 "k8s.io/klog"
 	klogv2 "k8s.io/klog/v2"
 
@@ -29,23 +27,6 @@ func unstableFlagHelp(help string) string {
 	return help + " (Setting this flag voids any guarantees of proper behavior of the operator.)"
 }
 
-// The valid levels for the --log-level flag.
-const (
-	logLevelDebug = "debug"
-	logLevelInfo  = "info"
-	logLevelWarn  = "warn"
-	logLevelError = "error"
-)
-
-var (
-	validLogLevels = []string{
-		logLevelDebug,
-		logLevelInfo,
-		logLevelWarn,
-		logLevelError,
-	}
-)
-
 func main() {
 	var kubeconfig *string
 	if home := homedir.HomeDir(); home != "" {
@@ -56,8 +37,6 @@ func main() {
 	var (
 		apiserverURL = flag.String("apiserver", "",
 			"URL to the Kubernetes API server.")
-		logLevel = flag.String("log-level", logLevelInfo,
-			fmt.Sprintf("Log level to use. Possible values: %s", strings.Join(validLogLevels, ", ")))
 		namespace = flag.String("namespace", operator.DefaultNamespace,
 			"Namespace in which the operator manages its resources.")
 
@@ -69,22 +48,68 @@ func main() {
 			"Priority class at which the collector pods are run.")
 		gcmEndpoint = flag.String("cloud-monitoring-endpoint", "",
 			"Override for the Cloud Monitoring endpoint to use for all collectors.")
+		cluster = flag.String("cluster", "",
+			"Value of the `cluster` target label injected onto every scraped target.")
+		location = flag.String("location", "",
+			"Value of the `location` target label injected onto every scraped target.")
 		caSelfSign = flag.Bool("ca-selfsign", true,
 			"Whether to self-sign or have kube-apiserver sign certificate key pair for TLS.")
+		tlsCertFile = flag.String("tls-cert-file", "",
+			"Path to the admission server's serving certificate. Only consulted when --ca-selfsign=false.")
+		tlsKeyFile = flag.String("tls-private-key-file", "",
+			"Path to the admission server's serving certificate key. Only consulted when --ca-selfsign=false.")
 		listenAddr = flag.String("listen-addr", ":8443",
 			"Address to listen to for incoming tcp connections.")
+		probeListenAddr = flag.String("probe-listen-addr", ":8081",
+			"Address to serve /healthz and /readyz on, separate from the TLS webhook port.")
+		shutdownTimeout = flag.Duration("shutdown-timeout", operator.DefaultShutdownTimeout,
+			"Time to wait for in-flight admission requests to drain before exiting.")
+
+		loggingFormat = flag.String("logging-format", "text",
+			"Log format to use, one of: text, json.")
+		logVerbosity = flag.Int("log-verbosity", 0,
+			"Number for the log level verbosity (klog-style, 0-6). Higher values log more detail.")
+		logFlushFrequency = flag.Duration("log-flush-frequency", 5*time.Second,
+			"Maximum time between log flushes.")
 	)
+	var vmodule logsapiv1.VModuleConfiguration
+	flag.Var(&vmodule, "vmodule",
+		"Comma-separated list of pattern=N settings for file-filtered log verbosity, e.g. 'webhook*=4'.")
 	flag.Parse()
 
-	logger, err := setupLogger(*logLevel)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Creating logger failed: %s", err)
+	// Build and validate the logging configuration through the component-base
+	// logs API before any client-go call runs, to avoid the well-known race
+	// where grpc/klog initialize their own loggers with defaults first.
+	loggingConfig := logsapiv1.NewLoggingConfiguration()
+	loggingConfig.Format = logsapiv1.Format(*loggingFormat)
+	loggingConfig.Verbosity = logsapiv1.VerbosityLevel(*logVerbosity)
+	loggingConfig.VModule = vmodule
+	loggingConfig.FlushFrequency.Duration.Duration = *logFlushFrequency
+
+	if errs := logsapiv1.Validate(loggingConfig, nil, nil); len(errs) > 0 {
+		fmt.Fprintf(os.Stderr, "Invalid logging configuration: %v", errs.ToAggregate())
+		os.Exit(2)
+	}
+	if err := logsapiv1.ValidateAndApply(loggingConfig, nil); err != nil {
+		fmt.Fprintf(os.Stderr, "Applying logging configuration failed: %s", err)
 		os.Exit(2)
 	}
+	// klog v1/v2 now flow through the sink installed by ValidateAndApply;
+	// route their output through the same logger and keep the CVE-2019-11250
+	// mitigation that limits verbosity so bearer tokens are never logged.
+	//
+	// No go-kit adapter is kept here: operator.New and AdmissionServer already
+	// take a logr.Logger directly (see pkg/operator/operator.go,
+	// pkg/operator/admission.go), and nothing else in this tree constructs or
+	// accepts a go-kit log.Logger, so there's no caller a compat shim would
+	// serve.
+	logger := klogv2.Background()
+	klogv1.ClampLevel(6)
+	klogv2.ClampLevel(6)
 
 	cfg, err := clientcmd.BuildConfigFromFlags(*apiserverURL, *kubeconfig)
 	if err != nil {
-		level.Error(logger).Log("msg", "building kubeconfig failed", "err", err)
+		logger.Error(err, "building kubeconfig failed")
 		os.Exit(1)
 	}
 	op, err := operator.New(logger, cfg, operator.Options{
@@ -93,11 +118,17 @@ func main() {
 		ImageConfigReloader:     *imageConfigReloader,
 		PriorityClass:           *priorityClass,
 		CloudMonitoringEndpoint: *gcmEndpoint,
+		Cluster:                 *cluster,
+		Location:                *location,
 		CASelfSign:              *caSelfSign,
+		TLSCertFile:             *tlsCertFile,
+		TLSKeyFile:              *tlsKeyFile,
 		ListenAddr:              *listenAddr,
+		ProbeListenAddr:         *probeListenAddr,
+		ShutdownTimeout:         *shutdownTimeout,
 	})
 	if err != nil {
-		level.Error(logger).Log("msg", "instantiating operator failed", "err", err)
+		logger.Error(err, "instantiating operator failed")
 		os.Exit(1)
 	}
 
@@ -112,7 +143,7 @@ func main() {
 			func() error {
 				select {
 				case <-term:
-					level.Info(logger).Log("msg", "received SIGTERM, exiting gracefully...")
+					logger.Info("received SIGTERM, exiting gracefully...")
 				case <-cancel:
 				}
 				return nil
@@ -127,11 +158,11 @@ func main() {
 		ctx, cancel := context.WithCancel(context.Background())
 		g.Add(
 			func() error {
-				if srv, err := op.InitAdmissionResources(ctx); err != nil {
+				as, err := op.InitAdmissionResources(ctx)
+				if err != nil {
 					return err
-				} else {
-					return srv.ListenAndServeTLS("", "")
 				}
+				return as.Run(ctx)
 			},
 			func(err error) {
 				cancel()
@@ -151,37 +182,7 @@ func main() {
 		)
 	}
 	if err := g.Run(); err != nil {
-		level.Error(logger).Log("msg", "exit with error", "err", err)
+		logger.Error(err, "exit with error")
 		os.Exit(1)
 	}
 }
-
-func setupLogger(lvl string) (log.Logger, error) {
-	logger := log.NewLogfmtLogger(log.NewSyncWriter(os.Stderr))
-
-	switch lvl {
-	case logLevelDebug:
-		logger = level.NewFilter(logger, level.AllowDebug())
-	case logLevelInfo:
-		logger = level.NewFilter(logger, level.AllowInfo())
-	case logLevelWarn:
-		logger = level.NewFilter(logger, level.AllowWarn())
-	case logLevelError:
-		logger = level.NewFilter(logger, level.AllowError())
-	default:
-		return nil, errors.Errorf("log level %q unknown, must be one of (%s)", lvl, strings.Join(validLogLevels, ", "))
-	}
-	logger = log.With(logger, "ts", log.DefaultTimestampUTC)
-
-	// Set caller to one function higher up in the stack as it will just reference the
-	// klog code with the default.
-	klogv1.SetLogger(log.With(logger, "component", "k8s_client_runtime", "caller", log.Caller(4)))
-	klogv2.SetLogger(log.With(logger, "component", "k8s_client_runtime", "caller", log.Caller(4)))
-	// Limit log level to address CVE-2019-11250, which would cause bearer tokens to be logged.
-	klogv1.ClampLevel(6)
-	klogv2.ClampLevel(6)
-
-	logger = log.With(logger, "caller", log.DefaultCaller)
-
-	return logger, nil
-}
@@ -1,45 +1,135 @@
 package operator
 
 import (
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"sync/atomic"
 
-	"github.com/go-kit/kit/log"
-	"github.com/go-kit/kit/log/level"
+	"github.com/go-logr/logr"
+	"github.com/google/gpe-collector/pkg/operator/admission"
 	"github.com/google/gpe-collector/pkg/operator/apis/monitoring/v1alpha1"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/scheme"
+	k8syaml "sigs.k8s.io/yaml"
 
 	v1 "k8s.io/api/admission/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
+	jsonpatch "gomodules.xyz/jsonpatch/v2"
 )
 
-type admitFn func(*v1.AdmissionReview) (*v1.AdmissionResponse, error)
+const (
+	validatePodMonitoringPath     = "/validate/podmonitorings"
+	validateServiceMonitoringPath = "/validate/servicemonitorings"
+	mutatePodMonitoringPath       = "/mutate/podmonitorings"
+	mutateServiceMonitoringPath   = "/mutate/servicemonitorings"
+	dryRunPodMonitoringPath       = "/dryrun/podmonitoring"
+
+	validatingWebhookConfigName = "gpe-operator-validation"
+	mutatingWebhookConfigName   = "gpe-operator-mutation"
+
+	// podMonitoringCRDName and serviceMonitoringCRDName are the CRDs whose
+	// spec.conversion.webhook is patched to point at this operator's
+	// /convert endpoint.
+	podMonitoringCRDName     = "podmonitorings." + v1alpha1.GroupName
+	serviceMonitoringCRDName = "servicemonitorings." + v1alpha1.GroupName
+
+	// defaultScrapeInterval is injected into PodMonitoring/ServiceMonitoring
+	// endpoints that don't specify one.
+	defaultScrapeInterval = "30s"
+)
+
+type admitFn func(context.Context, *v1.AdmissionReview) (*v1.AdmissionResponse, error)
+
+// mutateFn evaluates an incoming admission request and, on success, returns
+// an AdmissionResponse carrying a JSON Patch (RFC 6902) that applies the
+// defaults for the resource.
+type mutateFn func(*v1.AdmissionReview, Options) (*v1.AdmissionResponse, error)
 
 // AdmissionServer serves Kubernetes resource admission requests.
 type AdmissionServer struct {
-	logger  log.Logger
+	logger  logr.Logger
+	opts    Options
 	decoder runtime.Decoder
+
+	srv       *http.Server
+	probeSrv  *http.Server
+	certReady int32 // accessed atomically; set once the serving cert is in place
 }
 
-// NewAdmissionServer returns a new AdmissionServer with the provided logger.
-func NewAdmissionServer(logger log.Logger) *AdmissionServer {
+// NewAdmissionServer returns a new AdmissionServer with the provided logger
+// and operator options, the latter of which seed defaults applied by the
+// mutating webhooks.
+func NewAdmissionServer(logger logr.Logger, opts Options) *AdmissionServer {
 	return &AdmissionServer{
 		logger:  logger,
+		opts:    opts,
 		decoder: scheme.Codecs.UniversalDeserializer(),
 	}
 }
 
+// InitAdmissionResources registers the validating and mutating webhook
+// configurations for the operator's admission server and returns the
+// AdmissionServer that backs them, ready to be started with Run.
+func (o *Operator) InitAdmissionResources(ctx context.Context) (*AdmissionServer, error) {
+	as := NewAdmissionServer(o.logger, o.opts)
+
+	caBundle, servingCert, err := o.ensureCABundle(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "ensure CA bundle")
+	}
+
+	if err := o.upsertValidatingWebhookConfig(ctx, caBundle); err != nil {
+		return nil, errors.Wrap(err, "create validating webhook configuration")
+	}
+	if err := o.upsertMutatingWebhookConfig(ctx, caBundle); err != nil {
+		return nil, errors.Wrap(err, "create mutating webhook configuration")
+	}
+	if err := o.patchCRDConversionWebhook(ctx, podMonitoringCRDName, caBundle); err != nil {
+		return nil, errors.Wrap(err, "patch podmonitorings conversion webhook")
+	}
+	if err := o.patchCRDConversionWebhook(ctx, serviceMonitoringCRDName, caBundle); err != nil {
+		return nil, errors.Wrap(err, "patch servicemonitorings conversion webhook")
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(validatePodMonitoringPath, as.serveAdmission(admitPodMonitoring))
+	mux.HandleFunc(validateServiceMonitoringPath, as.serveAdmission(admitServiceMonitoring))
+	mux.HandleFunc(mutatePodMonitoringPath, as.serveMutation(mutatePodMonitoring))
+	mux.HandleFunc(mutateServiceMonitoringPath, as.serveMutation(mutateServiceMonitoring))
+	mux.HandleFunc(dryRunPodMonitoringPath, as.serveDryRunPodMonitoring())
+	mux.HandleFunc(convertPath, as.serveConversion())
+
+	as.srv = &http.Server{
+		Addr:      o.opts.ListenAddr,
+		Handler:   mux,
+		TLSConfig: &tls.Config{Certificates: []tls.Certificate{servingCert}},
+	}
+
+	probeMux := http.NewServeMux()
+	probeMux.HandleFunc(healthzPath, as.serveHealthz)
+	probeMux.HandleFunc(readyzPath, as.serveReadyz)
+	as.probeSrv = &http.Server{
+		Addr:    o.opts.ProbeListenAddr,
+		Handler: probeMux,
+	}
+
+	atomic.StoreInt32(&as.certReady, 1)
+
+	return as, nil
+}
+
 // serveAdmission returns a http handler closure that evaluates Kubernetes admission
 // requests. Encountered errors are logged and potentially returned in the admission
 // response.
 func (a *AdmissionServer) serveAdmission(admit admitFn) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		level.Debug(a.logger).Log(
-			"msg", "webhook called",
+		a.logger.V(1).Info("webhook called",
 			"method", r.Method,
 			"host", r.Host,
 			"path", r.URL.Path)
@@ -47,13 +137,13 @@ func (a *AdmissionServer) serveAdmission(admit admitFn) http.HandlerFunc {
 		var req, resp v1.AdmissionReview
 		// Read, decode, and evaluate admission request.
 		if data, err := ioutil.ReadAll(r.Body); err != nil {
-			level.Error(a.logger).Log("msg", "reading request body", "err", err)
+			a.logger.Error(err, "reading request body")
 			resp.Response = toAdmissionResponse(err)
 		} else if _, _, err := a.decoder.Decode(data, nil, &req); err != nil {
-			level.Error(a.logger).Log("msg", "decoding request body", "err", err)
+			a.logger.Error(err, "decoding request body")
 			resp.Response = toAdmissionResponse(err)
-		} else if ar, err := admit(&req); err != nil {
-			level.Error(a.logger).Log("msg", "admitting admission request", "err", err)
+		} else if ar, err := admit(r.Context(), &req); err != nil {
+			a.logger.Error(err, "admitting admission request")
 			resp.Response = toAdmissionResponse(err)
 		} else {
 			resp.Response = ar
@@ -68,16 +158,57 @@ func (a *AdmissionServer) serveAdmission(admit admitFn) http.HandlerFunc {
 
 		// Write the admission response.
 		if respBytes, err := json.Marshal(resp); err != nil {
-			level.Error(a.logger).Log("msg", "encoding response body", "err", err)
+			a.logger.Error(err, "encoding response body")
 		} else if _, err := w.Write(respBytes); err != nil {
-			level.Error(a.logger).Log("msg", "writing response body", "err", err)
+			a.logger.Error(err, "writing response body")
 		}
 	}
 }
 
-// admitPodMonitoring evaluates incoming PodMonitoring resources to ensure
-// they are a valid resource.
-func admitPodMonitoring(ar *v1.AdmissionReview) (*v1.AdmissionResponse, error) {
+// serveMutation returns a http handler closure that evaluates Kubernetes
+// mutating admission requests, applying the patch returned by mutate to the
+// admission response.
+func (a *AdmissionServer) serveMutation(mutate mutateFn) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		a.logger.V(1).Info("mutating webhook called",
+			"method", r.Method,
+			"host", r.Host,
+			"path", r.URL.Path)
+
+		var req, resp v1.AdmissionReview
+		if data, err := ioutil.ReadAll(r.Body); err != nil {
+			a.logger.Error(err, "reading request body")
+			resp.Response = toAdmissionResponse(err)
+		} else if _, _, err := a.decoder.Decode(data, nil, &req); err != nil {
+			a.logger.Error(err, "decoding request body")
+			resp.Response = toAdmissionResponse(err)
+		} else if ar, err := mutate(&req, a.opts); err != nil {
+			a.logger.Error(err, "mutating admission request")
+			resp.Response = toAdmissionResponse(err)
+		} else {
+			resp.Response = ar
+		}
+		if req.Request != nil {
+			resp.APIVersion = req.APIVersion
+			resp.Kind = req.Kind
+			resp.Response.UID = req.Request.UID
+		}
+
+		if respBytes, err := json.Marshal(resp); err != nil {
+			a.logger.Error(err, "encoding response body")
+		} else if _, err := w.Write(respBytes); err != nil {
+			a.logger.Error(err, "writing response body")
+		}
+	}
+}
+
+// admitPodMonitoring evaluates incoming PodMonitoring resources against the
+// validator chain, aggregating every violation into the rejection and every
+// non-fatal issue into AdmissionResponse.Warnings rather than stopping at
+// the first problem. Validation failures are returned as a rejecting
+// AdmissionResponse rather than a Go error, so that warnings collected
+// alongside a rejection still reach the caller instead of being discarded.
+func admitPodMonitoring(ctx context.Context, ar *v1.AdmissionReview) (*v1.AdmissionResponse, error) {
 	var pm = &v1alpha1.PodMonitoring{}
 	// Ensure the requested resource is a PodMonitoring.
 	if ar.Request.Resource != v1alpha1.PodMonitoringResource() {
@@ -85,17 +216,22 @@ func admitPodMonitoring(ar *v1.AdmissionReview) (*v1.AdmissionResponse, error) {
 		// Unmarshall payload to PodMonitoring stuct.
 	} else if err := json.Unmarshal(ar.Request.Object.Raw, pm); err != nil {
 		return nil, errors.Wrap(err, "unmarshalling admission request to podmonitoring")
-		// Check valid relabel mappings.
-	} else if _, err := labelMappingRelabelConfigs(pm.Spec.TargetLabels.FromPod, podLabelPrefix); err != nil {
-		return nil, errors.Wrap(err, "checking label mappings")
 	}
 
-	return &v1.AdmissionResponse{Allowed: true}, nil
+	warnings, err := newValidatorChain().Validate(ctx, &admission.Target{
+		Kind:         "PodMonitoring",
+		Endpoints:    pm.Spec.Endpoints,
+		TargetLabels: pm.Spec.TargetLabels,
+	})
+	if err != nil {
+		return rejectionResponse(err, warnings), nil
+	}
+	return &v1.AdmissionResponse{Allowed: true, Warnings: warningStrings(warnings)}, nil
 }
 
-// admitServiceMonitoring evaluates incoming ServiceMonitoring resources to ensure
-// they are a valid resource.
-func admitServiceMonitoring(ar *v1.AdmissionReview) (*v1.AdmissionResponse, error) {
+// admitServiceMonitoring evaluates incoming ServiceMonitoring resources
+// against the validator chain, analogous to admitPodMonitoring.
+func admitServiceMonitoring(ctx context.Context, ar *v1.AdmissionReview) (*v1.AdmissionResponse, error) {
 	var sm = &v1alpha1.ServiceMonitoring{}
 	// Ensure the requested resource is a ServiceMonitoring.
 	if ar.Request.Resource != v1alpha1.ServiceMonitoringResource() {
@@ -103,14 +239,181 @@ func admitServiceMonitoring(ar *v1.AdmissionReview) (*v1.AdmissionResponse, erro
 		// Unmarshall payload to ServiceMonitoring stuct.
 	} else if err := json.Unmarshal(ar.Request.Object.Raw, sm); err != nil {
 		return nil, errors.Wrap(err, "unmarshalling admission request to servicemonitoring")
-		// Check valid relabel mappings.
-	} else if _, err := labelMappingRelabelConfigs(sm.Spec.TargetLabels.FromPod, podLabelPrefix); err != nil {
-		return nil, errors.Wrap(err, "checking pod label mappings")
-	} else if _, err := labelMappingRelabelConfigs(sm.Spec.TargetLabels.FromService, serviceLabelPrefix); err != nil {
-		return nil, errors.Wrap(err, "checking service label mappings")
 	}
 
-	return &v1.AdmissionResponse{Allowed: true}, nil
+	warnings, err := newValidatorChain().Validate(ctx, &admission.Target{
+		Kind:         "ServiceMonitoring",
+		Endpoints:    sm.Spec.Endpoints,
+		TargetLabels: sm.Spec.TargetLabels,
+	})
+	if err != nil {
+		return rejectionResponse(err, warnings), nil
+	}
+	return &v1.AdmissionResponse{Allowed: true, Warnings: warningStrings(warnings)}, nil
+}
+
+// dryRunResponse is returned by the /dryrun/podmonitoring diagnostics
+// endpoint: the scrape config(s) that would be generated for the submitted
+// resource, plus the same validator report used during admission.
+type dryRunResponse struct {
+	ScrapeConfig string   `json:"scrapeConfig,omitempty"`
+	Warnings     []string `json:"warnings,omitempty"`
+	Errors       []string `json:"errors,omitempty"`
+}
+
+// serveDryRunPodMonitoring returns a handler for POST /dryrun/podmonitoring
+// that renders the scrape config and validator report for a submitted
+// PodMonitoring resource without requiring it to be created in the cluster,
+// similar in spirit to `promtool check`.
+func (a *AdmissionServer) serveDryRunPodMonitoring() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, errors.Wrap(err, "reading request body").Error(), http.StatusBadRequest)
+			return
+		}
+		pm := &v1alpha1.PodMonitoring{}
+		if err := k8syaml.Unmarshal(data, pm); err != nil {
+			http.Error(w, errors.Wrap(err, "decoding PodMonitoring").Error(), http.StatusBadRequest)
+			return
+		}
+
+		var resp dryRunResponse
+		warnings, vErr := newValidatorChain().Validate(r.Context(), &admission.Target{
+			Kind:         "PodMonitoring",
+			Endpoints:    pm.Spec.Endpoints,
+			TargetLabels: pm.Spec.TargetLabels,
+		})
+		resp.Warnings = warningStrings(warnings)
+		if vErr != nil {
+			resp.Errors = append(resp.Errors, vErr.Error())
+		}
+
+		if cfg, err := renderPodMonitoringScrapeConfig(pm, a.opts); err != nil {
+			resp.Errors = append(resp.Errors, errors.Wrap(err, "rendering scrape config").Error())
+		} else {
+			resp.ScrapeConfig = string(cfg)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			a.logger.Error(err, "writing dry-run response body")
+		}
+	}
+}
+
+// mutatePodMonitoring fills in defaults for an incoming PodMonitoring resource
+// and returns the JSON Patch required to apply them.
+func mutatePodMonitoring(ar *v1.AdmissionReview, opts Options) (*v1.AdmissionResponse, error) {
+	var pm = &v1alpha1.PodMonitoring{}
+	if ar.Request.Resource != v1alpha1.PodMonitoringResource() {
+		return nil, fmt.Errorf("expected resource to be %v, but received %v", v1alpha1.PodMonitoringResource(), ar.Request.Resource)
+	} else if err := json.Unmarshal(ar.Request.Object.Raw, pm); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling admission request to podmonitoring")
+	}
+
+	orig, err := json.Marshal(pm)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling parsed podmonitoring")
+	}
+	defaulted := pm.DeepCopy()
+	defaultPodMonitoring(defaulted, opts)
+
+	return patchResponse(orig, defaulted)
+}
+
+// mutateServiceMonitoring fills in defaults for an incoming ServiceMonitoring
+// resource and returns the JSON Patch required to apply them.
+func mutateServiceMonitoring(ar *v1.AdmissionReview, opts Options) (*v1.AdmissionResponse, error) {
+	var sm = &v1alpha1.ServiceMonitoring{}
+	if ar.Request.Resource != v1alpha1.ServiceMonitoringResource() {
+		return nil, fmt.Errorf("expected resource to be %v, but received %v", v1alpha1.ServiceMonitoringResource(), ar.Request.Resource)
+	} else if err := json.Unmarshal(ar.Request.Object.Raw, sm); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling admission request to servicemonitoring")
+	}
+
+	orig, err := json.Marshal(sm)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling parsed servicemonitoring")
+	}
+	defaulted := sm.DeepCopy()
+	defaultServiceMonitoring(defaulted, opts)
+
+	return patchResponse(orig, defaulted)
+}
+
+// defaultPodMonitoring fills in cluster-wide defaults for pm in place: an
+// empty TargetLabels map and a default scrape interval/port for endpoints
+// that don't set one. The operator-wide cluster/location target labels are
+// injected at scrape config render time instead of here, since their values
+// come from Options rather than anything on the PodMonitoring object itself
+// (see renderPodMonitoringScrapeConfig).
+func defaultPodMonitoring(pm *v1alpha1.PodMonitoring, opts Options) {
+	if pm.Spec.TargetLabels.FromPod == nil {
+		pm.Spec.TargetLabels.FromPod = []v1alpha1.LabelMapping{}
+	}
+
+	for i := range pm.Spec.Endpoints {
+		if pm.Spec.Endpoints[i].Interval == "" {
+			pm.Spec.Endpoints[i].Interval = defaultScrapeInterval
+		}
+		if pm.Spec.Endpoints[i].Port.StrVal == "" && pm.Spec.Endpoints[i].Port.IntVal == 0 {
+			pm.Spec.Endpoints[i].Port = intstr.FromString("metrics")
+		}
+	}
+}
+
+// defaultServiceMonitoring fills in cluster-wide defaults for sm in place,
+// analogous to defaultPodMonitoring.
+func defaultServiceMonitoring(sm *v1alpha1.ServiceMonitoring, opts Options) {
+	if sm.Spec.TargetLabels.FromPod == nil {
+		sm.Spec.TargetLabels.FromPod = []v1alpha1.LabelMapping{}
+	}
+	if sm.Spec.TargetLabels.FromService == nil {
+		sm.Spec.TargetLabels.FromService = []v1alpha1.LabelMapping{}
+	}
+
+	for i := range sm.Spec.Endpoints {
+		if sm.Spec.Endpoints[i].Interval == "" {
+			sm.Spec.Endpoints[i].Interval = defaultScrapeInterval
+		}
+		if sm.Spec.Endpoints[i].Port.StrVal == "" && sm.Spec.Endpoints[i].Port.IntVal == 0 {
+			sm.Spec.Endpoints[i].Port = intstr.FromString("metrics")
+		}
+	}
+}
+
+// patchResponse diffs original against defaulted and returns an allowed
+// AdmissionResponse carrying the resulting JSON Patch (RFC 6902), the only
+// patch format AdmissionResponse.Patch supports. original must be the
+// re-marshaled, already-parsed object rather than the raw request bytes: the
+// typed structs drop fields like status that the apiserver sends on UPDATE,
+// and diffing against the raw bytes would patch those fields away.
+func patchResponse(original []byte, defaulted interface{}) (*v1.AdmissionResponse, error) {
+	defaultedRaw, err := json.Marshal(defaulted)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling defaulted object")
+	}
+	ops, err := jsonpatch.CreatePatch(original, defaultedRaw)
+	if err != nil {
+		return nil, errors.Wrap(err, "computing JSON patch")
+	}
+
+	resp := &v1.AdmissionResponse{Allowed: true}
+	if len(ops) > 0 {
+		patch, err := json.Marshal(ops)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshalling JSON patch")
+		}
+		pt := v1.PatchTypeJSONPatch
+		resp.Patch = patch
+		resp.PatchType = &pt
+	}
+	return resp, nil
 }
 
 // toAdmissionResponse is a helper function that returns an AdmissionResponse
@@ -124,3 +427,12 @@ func toAdmissionResponse(err error) *v1.AdmissionResponse {
 		},
 	}
 }
+
+// rejectionResponse is like toAdmissionResponse, but also carries warnings
+// collected alongside the rejecting error, so a resource with both a hard
+// violation and non-fatal issues surfaces all of them in one `kubectl apply`.
+func rejectionResponse(err error, warnings []admission.Warning) *v1.AdmissionResponse {
+	resp := toAdmissionResponse(err)
+	resp.Warnings = warningStrings(warnings)
+	return resp
+}
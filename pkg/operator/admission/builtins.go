@@ -0,0 +1,95 @@
+package admission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// minScrapeInterval and maxScrapeInterval bound the interval accepted by
+	// ScrapeIntervalBoundsValidator: shorter risks hammering targets, longer
+	// risks starving alerting/dashboards of samples.
+	minScrapeInterval = 5 * time.Second
+	maxScrapeInterval = time.Hour
+)
+
+// PortExistenceValidator checks that every endpoint names a port, either by
+// number or by the name of a port declared on the underlying pod/service.
+type PortExistenceValidator struct{}
+
+// Name implements Validator.
+func (PortExistenceValidator) Name() string { return "port-existence" }
+
+// Validate implements Validator.
+func (PortExistenceValidator) Validate(_ context.Context, t *Target) ([]Warning, error) {
+	var errs []string
+	for i, ep := range t.Endpoints {
+		if ep.Port.StrVal == "" && ep.Port.IntVal == 0 {
+			errs = append(errs, fmt.Sprintf("endpoint[%d]: port must be set", i))
+		}
+	}
+	return nil, aggregate(errs)
+}
+
+// ScrapeIntervalBoundsValidator checks that endpoint scrape intervals parse
+// and fall within a sane range.
+type ScrapeIntervalBoundsValidator struct{}
+
+// Name implements Validator.
+func (ScrapeIntervalBoundsValidator) Name() string { return "scrape-interval-bounds" }
+
+// Validate implements Validator.
+func (ScrapeIntervalBoundsValidator) Validate(_ context.Context, t *Target) ([]Warning, error) {
+	var errs []string
+	var warnings []Warning
+	for i, ep := range t.Endpoints {
+		if ep.Interval == "" {
+			continue
+		}
+		d, err := time.ParseDuration(ep.Interval)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("endpoint[%d]: invalid interval %q: %s", i, ep.Interval, err))
+			continue
+		}
+		switch {
+		case d < minScrapeInterval:
+			errs = append(errs, fmt.Sprintf("endpoint[%d]: interval %s is below the minimum of %s", i, d, minScrapeInterval))
+		case d > maxScrapeInterval:
+			warnings = append(warnings, Warning(fmt.Sprintf("endpoint[%d]: interval %s is unusually long (over %s)", i, d, maxScrapeInterval)))
+		}
+	}
+	return warnings, aggregate(errs)
+}
+
+// EndpointSchemeValidator sanity-checks the endpoint's transport scheme.
+// Per-endpoint TLS client configuration isn't part of the v1alpha1 API yet
+// (see the v1alpha2 conversion work), so for now this only guards against a
+// typo'd scheme.
+type EndpointSchemeValidator struct{}
+
+// Name implements Validator.
+func (EndpointSchemeValidator) Name() string { return "endpoint-tls" }
+
+// Validate implements Validator.
+func (EndpointSchemeValidator) Validate(_ context.Context, t *Target) ([]Warning, error) {
+	var errs []string
+	for i, ep := range t.Endpoints {
+		switch ep.Scheme {
+		case "", "http", "https":
+		default:
+			errs = append(errs, fmt.Sprintf("endpoint[%d]: unsupported scheme %q, must be http or https", i, ep.Scheme))
+		}
+	}
+	return nil, aggregate(errs)
+}
+
+// aggregate joins errs into a single error, or returns nil if errs is empty.
+func aggregate(errs []string) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(errs, "; "))
+}
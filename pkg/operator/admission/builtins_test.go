@@ -0,0 +1,75 @@
+package admission
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/gpe-collector/pkg/operator/apis/monitoring/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestScrapeIntervalBoundsValidator(t *testing.T) {
+	v := ScrapeIntervalBoundsValidator{}
+
+	cases := []struct {
+		name     string
+		interval string
+		wantErr  bool
+		wantWarn bool
+	}{
+		{name: "empty is skipped", interval: ""},
+		{name: "in range", interval: "30s"},
+		{name: "too short", interval: "1s", wantErr: true},
+		{name: "too long warns", interval: "2h", wantWarn: true},
+		{name: "unparseable", interval: "not-a-duration", wantErr: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			target := &Target{Endpoints: []v1alpha1.ScrapeEndpoint{{Interval: c.interval}}}
+			warnings, err := v.Validate(context.Background(), target)
+			if (err != nil) != c.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, c.wantErr)
+			}
+			if (len(warnings) > 0) != c.wantWarn {
+				t.Errorf("warnings = %v, wantWarn %v", warnings, c.wantWarn)
+			}
+		})
+	}
+}
+
+// TestScrapeIntervalBoundsValidatorPreservesPercent checks that a
+// user-supplied interval containing a literal "%" survives into the
+// aggregated error message verbatim, rather than being interpreted as a
+// fmt verb and corrupted.
+func TestScrapeIntervalBoundsValidatorPreservesPercent(t *testing.T) {
+	v := ScrapeIntervalBoundsValidator{}
+	target := &Target{Endpoints: []v1alpha1.ScrapeEndpoint{{Interval: "100%s"}}}
+
+	_, err := v.Validate(context.Background(), target)
+	if err == nil {
+		t.Fatal("expected an error for an unparseable interval")
+	}
+	if strings.Contains(err.Error(), "MISSING") {
+		t.Errorf("error message was corrupted by fmt verb interpretation: %q", err.Error())
+	}
+	if !strings.Contains(err.Error(), "100%s") {
+		t.Errorf("error message %q doesn't contain the original interval %q", err.Error(), "100%s")
+	}
+}
+
+func TestPortExistenceValidator(t *testing.T) {
+	v := PortExistenceValidator{}
+
+	if _, err := v.Validate(context.Background(), &Target{
+		Endpoints: []v1alpha1.ScrapeEndpoint{{Port: intstr.FromString("metrics")}},
+	}); err != nil {
+		t.Errorf("expected no error for a named port, got %s", err)
+	}
+
+	if _, err := v.Validate(context.Background(), &Target{
+		Endpoints: []v1alpha1.ScrapeEndpoint{{}},
+	}); err == nil {
+		t.Error("expected an error when no port is set")
+	}
+}
@@ -0,0 +1,68 @@
+// Package admission implements a pluggable chain of validation rules for
+// PodMonitoring and ServiceMonitoring admission requests. Validators run
+// independently so that every violation in a resource is reported at once,
+// rather than a `kubectl apply` only ever seeing the first problem.
+package admission
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/gpe-collector/pkg/operator/apis/monitoring/v1alpha1"
+)
+
+// Warning is a non-fatal admission finding. Warnings are surfaced through the
+// AdmissionResponse.Warnings field, which kubectl renders via the
+// `Warning:` response header mechanism, instead of rejecting the request.
+type Warning string
+
+// Target bundles the fields validators need from either a PodMonitoring or a
+// ServiceMonitoring resource.
+type Target struct {
+	// Kind is "PodMonitoring" or "ServiceMonitoring".
+	Kind         string
+	Endpoints    []v1alpha1.ScrapeEndpoint
+	TargetLabels v1alpha1.TargetLabels
+}
+
+// Validator checks one aspect of a Target.
+type Validator interface {
+	// Name identifies the validator in aggregated error messages and in the
+	// /dryrun diagnostics report.
+	Name() string
+	// Validate inspects t and returns any non-fatal warnings plus an error
+	// describing violations serious enough to reject the request.
+	Validate(ctx context.Context, t *Target) ([]Warning, error)
+}
+
+// Chain runs a fixed set of validators against a Target, aggregating every
+// violation rather than stopping at the first error.
+type Chain struct {
+	validators []Validator
+}
+
+// NewChain returns a Chain that runs validators, in order, on every call to
+// Validate.
+func NewChain(validators ...Validator) *Chain {
+	return &Chain{validators: validators}
+}
+
+// Validate runs every validator in the chain against t and returns the
+// combined warnings plus a single error aggregating every violation.
+func (c *Chain) Validate(ctx context.Context, t *Target) ([]Warning, error) {
+	var warnings []Warning
+	var violations []string
+
+	for _, v := range c.validators {
+		ws, err := v.Validate(ctx, t)
+		warnings = append(warnings, ws...)
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("%s: %s", v.Name(), err))
+		}
+	}
+	if len(violations) == 0 {
+		return warnings, nil
+	}
+	return warnings, fmt.Errorf("%d validator(s) failed:\n%s", len(violations), strings.Join(violations, "\n"))
+}
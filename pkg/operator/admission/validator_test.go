@@ -0,0 +1,55 @@
+package admission
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type stubValidator struct {
+	name     string
+	warnings []Warning
+	err      error
+}
+
+func (s stubValidator) Name() string { return s.name }
+
+func (s stubValidator) Validate(context.Context, *Target) ([]Warning, error) {
+	return s.warnings, s.err
+}
+
+// TestChainAggregatesWarningsAndErrors checks that Chain collects warnings
+// from every validator and aggregates every validator's error into a single
+// combined error, rather than stopping at the first failure.
+func TestChainAggregatesWarningsAndErrors(t *testing.T) {
+	chain := NewChain(
+		stubValidator{name: "a", warnings: []Warning{"a-warning"}},
+		stubValidator{name: "b", err: errors.New("b failed")},
+		stubValidator{name: "c", err: errors.New("c failed")},
+	)
+
+	warnings, err := chain.Validate(context.Background(), &Target{})
+	if len(warnings) != 1 || warnings[0] != "a-warning" {
+		t.Errorf("warnings = %v, want [a-warning]", warnings)
+	}
+	if err == nil {
+		t.Fatal("expected an aggregated error")
+	}
+	if got := err.Error(); !strings.Contains(got, "b failed") || !strings.Contains(got, "c failed") {
+		t.Errorf("aggregated error %q missing one of the validator failures", got)
+	}
+}
+
+// TestChainAllPass checks that Chain returns a nil error when every
+// validator passes, even if some emit warnings.
+func TestChainAllPass(t *testing.T) {
+	chain := NewChain(stubValidator{name: "a", warnings: []Warning{"fyi"}})
+	warnings, err := chain.Validate(context.Background(), &Target{})
+	if err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings = %v, want 1 entry", warnings)
+	}
+}
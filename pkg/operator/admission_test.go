@@ -0,0 +1,80 @@
+package operator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/gpe-collector/pkg/operator/apis/monitoring/v1alpha1"
+	jsonpatch "gomodules.xyz/jsonpatch/v2"
+	v1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// TestMutatePodMonitoringPatchesDefaults checks that mutatePodMonitoring
+// returns a JSON Patch that fills in the endpoint defaults applied by
+// defaultPodMonitoring, without touching fields the request already set.
+func TestMutatePodMonitoringPatchesDefaults(t *testing.T) {
+	pm := &v1alpha1.PodMonitoring{
+		ObjectMeta: metav1.ObjectMeta{Name: "pm", Namespace: "ns"},
+		Spec: v1alpha1.PodMonitoringSpec{
+			Endpoints: []v1alpha1.ScrapeEndpoint{{Path: "/metrics"}},
+		},
+	}
+	raw, err := json.Marshal(pm)
+	if err != nil {
+		t.Fatalf("marshal PodMonitoring: %s", err)
+	}
+
+	ar := &v1.AdmissionReview{Request: &v1.AdmissionRequest{
+		Resource: v1alpha1.PodMonitoringResource(),
+		Object:   runtime.RawExtension{Raw: raw},
+	}}
+
+	resp, err := mutatePodMonitoring(ar, Options{})
+	if err != nil {
+		t.Fatalf("mutatePodMonitoring: %s", err)
+	}
+	if !resp.Allowed {
+		t.Fatalf("expected response to be allowed, got %+v", resp)
+	}
+	if resp.Patch == nil {
+		t.Fatal("expected a non-nil patch")
+	}
+
+	var ops []jsonpatch.Operation
+	if err := json.Unmarshal(resp.Patch, &ops); err != nil {
+		t.Fatalf("unmarshal patch: %s", err)
+	}
+
+	want := map[string]interface{}{
+		"/spec/endpoints/0/interval": defaultScrapeInterval,
+		"/spec/endpoints/0/port":     "metrics",
+	}
+	for _, op := range ops {
+		if v, ok := want[op.Path]; ok {
+			if op.Operation != "add" && op.Operation != "replace" {
+				t.Errorf("op for %s: unexpected operation %q", op.Path, op.Operation)
+			}
+			if op.Value != v {
+				t.Errorf("op for %s: value = %v, want %v", op.Path, op.Value, v)
+			}
+			delete(want, op.Path)
+		}
+	}
+	for path := range want {
+		t.Errorf("missing expected patch op for %s", path)
+	}
+}
+
+// TestMutatePodMonitoringWrongResourceErrors checks that mutatePodMonitoring
+// rejects a request for a resource it isn't responsible for, rather than
+// silently defaulting it.
+func TestMutatePodMonitoringWrongResourceErrors(t *testing.T) {
+	ar := &v1.AdmissionReview{Request: &v1.AdmissionRequest{
+		Resource: v1alpha1.ServiceMonitoringResource(),
+	}}
+	if _, err := mutatePodMonitoring(ar, Options{}); err == nil {
+		t.Fatal("expected an error for a ServiceMonitoring resource")
+	}
+}
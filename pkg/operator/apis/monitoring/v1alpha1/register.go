@@ -0,0 +1,55 @@
+// Package v1alpha1 contains API types for the monitoring.googleapis.com/v1alpha1
+// group: the PodMonitoring and ServiceMonitoring custom resources that
+// declare Prometheus scrape targets for the operator to collect.
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GroupName is the API group this package's types belong to.
+const GroupName = "monitoring.googleapis.com"
+
+// SchemeGroupVersion is the identifier for this API's group and version.
+var SchemeGroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1alpha1"}
+
+// Resource returns a GroupResource for the given resource name in this group.
+func Resource(resource string) schema.GroupResource {
+	return SchemeGroupVersion.WithResource(resource).GroupResource()
+}
+
+// PodMonitoringResource returns the GroupVersionResource admission requests
+// for PodMonitoring resources are addressed to.
+func PodMonitoringResource() metav1.GroupVersionResource {
+	return toMetaGVR(SchemeGroupVersion.WithResource("podmonitorings"))
+}
+
+// ServiceMonitoringResource returns the GroupVersionResource admission
+// requests for ServiceMonitoring resources are addressed to.
+func ServiceMonitoringResource() metav1.GroupVersionResource {
+	return toMetaGVR(SchemeGroupVersion.WithResource("servicemonitorings"))
+}
+
+func toMetaGVR(gvr schema.GroupVersionResource) metav1.GroupVersionResource {
+	return metav1.GroupVersionResource{Group: gvr.Group, Version: gvr.Version, Resource: gvr.Resource}
+}
+
+var (
+	// SchemeBuilder collects functions that add types to a scheme.
+	SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
+	// AddToScheme applies all the stored functions to the given scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
+
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(SchemeGroupVersion,
+		&PodMonitoring{},
+		&PodMonitoringList{},
+		&ServiceMonitoring{},
+		&ServiceMonitoringList{},
+	)
+	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
+	return nil
+}
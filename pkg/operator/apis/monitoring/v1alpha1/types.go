@@ -0,0 +1,104 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PodMonitoring defines a set of Prometheus scrape targets discovered from
+// pods matching a label selector.
+type PodMonitoring struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PodMonitoringSpec `json:"spec"`
+}
+
+// PodMonitoringSpec specifies how to discover and scrape PodMonitoring
+// targets.
+type PodMonitoringSpec struct {
+	// Selector selects the pods this resource discovers targets from.
+	Selector metav1.LabelSelector `json:"selector"`
+	// Endpoints specifies the scrape endpoints exposed by the selected pods.
+	Endpoints []ScrapeEndpoint `json:"endpoints"`
+	// TargetLabels configures labels copied onto scraped targets.
+	TargetLabels TargetLabels `json:"targetLabels,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PodMonitoringList is a list of PodMonitorings.
+type PodMonitoringList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []PodMonitoring `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServiceMonitoring defines a set of Prometheus scrape targets discovered
+// from the endpoints of services matching a label selector.
+type ServiceMonitoring struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ServiceMonitoringSpec `json:"spec"`
+}
+
+// ServiceMonitoringSpec specifies how to discover and scrape ServiceMonitoring
+// targets.
+type ServiceMonitoringSpec struct {
+	// Selector selects the services this resource discovers targets from.
+	Selector metav1.LabelSelector `json:"selector"`
+	// Endpoints specifies the scrape endpoints exposed by the selected
+	// services.
+	Endpoints []ScrapeEndpoint `json:"endpoints"`
+	// TargetLabels configures labels copied onto scraped targets.
+	TargetLabels TargetLabels `json:"targetLabels,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServiceMonitoringList is a list of ServiceMonitorings.
+type ServiceMonitoringList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ServiceMonitoring `json:"items"`
+}
+
+// ScrapeEndpoint specifies a Prometheus scrape endpoint exposed by a target.
+type ScrapeEndpoint struct {
+	// Port on the target to scrape, by name or number.
+	Port intstr.IntOrString `json:"port"`
+	// Path from which to scrape metrics. Defaults to "/metrics".
+	Path string `json:"path,omitempty"`
+	// Scheme to scrape over, "http" or "https". Defaults to "http".
+	Scheme string `json:"scheme,omitempty"`
+	// Interval at which the endpoint is scraped.
+	Interval string `json:"interval,omitempty"`
+}
+
+// TargetLabels configures labels copied from the discovered pod/service onto
+// the scraped target.
+type TargetLabels struct {
+	// FromPod copies labels from the discovered pod onto the target.
+	FromPod []LabelMapping `json:"fromPod,omitempty"`
+	// FromService copies labels from the discovered service onto the
+	// target. Only valid on ServiceMonitoring.
+	FromService []LabelMapping `json:"fromService,omitempty"`
+}
+
+// LabelMapping specifies how a label on the discovered resource is copied to
+// a label on the scraped target.
+type LabelMapping struct {
+	// From is the resource label to copy.
+	From string `json:"from"`
+	// To is the target label to populate. Defaults to From.
+	To string `json:"to,omitempty"`
+}
@@ -0,0 +1,144 @@
+package v1alpha2
+
+import (
+	"github.com/google/gpe-collector/pkg/operator/apis/monitoring/v1alpha1"
+)
+
+// ConvertPodMonitoringFrom converts a v1alpha1 PodMonitoring to v1alpha2,
+// preserving metadata (including resourceVersion) and every field common to
+// both versions. Fields new to v1alpha2 (per-endpoint auth, static targets)
+// have no v1alpha1 representation and are left unset.
+func ConvertPodMonitoringFrom(in *v1alpha1.PodMonitoring) *PodMonitoring {
+	out := &PodMonitoring{
+		TypeMeta:   in.TypeMeta,
+		ObjectMeta: *in.ObjectMeta.DeepCopy(),
+		Spec: PodMonitoringSpec{
+			Selector:     *in.Spec.Selector.DeepCopy(),
+			Endpoints:    convertEndpointsFrom(in.Spec.Endpoints),
+			TargetLabels: convertTargetLabelsFrom(in.Spec.TargetLabels),
+		},
+	}
+	out.APIVersion = SchemeGroupVersion.String()
+	out.Kind = "PodMonitoring"
+	return out
+}
+
+// ConvertPodMonitoringTo converts a v1alpha2 PodMonitoring back to
+// v1alpha1, dropping fields that version doesn't support (per-endpoint
+// auth, static targets).
+func ConvertPodMonitoringTo(in *PodMonitoring) *v1alpha1.PodMonitoring {
+	out := &v1alpha1.PodMonitoring{
+		TypeMeta:   in.TypeMeta,
+		ObjectMeta: *in.ObjectMeta.DeepCopy(),
+		Spec: v1alpha1.PodMonitoringSpec{
+			Selector:     *in.Spec.Selector.DeepCopy(),
+			Endpoints:    convertEndpointsTo(in.Spec.Endpoints),
+			TargetLabels: convertTargetLabelsTo(in.Spec.TargetLabels),
+		},
+	}
+	out.APIVersion = v1alpha1.SchemeGroupVersion.String()
+	out.Kind = "PodMonitoring"
+	return out
+}
+
+// ConvertServiceMonitoringFrom converts a v1alpha1 ServiceMonitoring to
+// v1alpha2, analogous to ConvertPodMonitoringFrom.
+func ConvertServiceMonitoringFrom(in *v1alpha1.ServiceMonitoring) *ServiceMonitoring {
+	out := &ServiceMonitoring{
+		TypeMeta:   in.TypeMeta,
+		ObjectMeta: *in.ObjectMeta.DeepCopy(),
+		Spec: ServiceMonitoringSpec{
+			Selector:     *in.Spec.Selector.DeepCopy(),
+			Endpoints:    convertEndpointsFrom(in.Spec.Endpoints),
+			TargetLabels: convertTargetLabelsFrom(in.Spec.TargetLabels),
+		},
+	}
+	out.APIVersion = SchemeGroupVersion.String()
+	out.Kind = "ServiceMonitoring"
+	return out
+}
+
+// ConvertServiceMonitoringTo converts a v1alpha2 ServiceMonitoring back to
+// v1alpha1, analogous to ConvertPodMonitoringTo.
+func ConvertServiceMonitoringTo(in *ServiceMonitoring) *v1alpha1.ServiceMonitoring {
+	out := &v1alpha1.ServiceMonitoring{
+		TypeMeta:   in.TypeMeta,
+		ObjectMeta: *in.ObjectMeta.DeepCopy(),
+		Spec: v1alpha1.ServiceMonitoringSpec{
+			Selector:     *in.Spec.Selector.DeepCopy(),
+			Endpoints:    convertEndpointsTo(in.Spec.Endpoints),
+			TargetLabels: convertTargetLabelsTo(in.Spec.TargetLabels),
+		},
+	}
+	out.APIVersion = v1alpha1.SchemeGroupVersion.String()
+	out.Kind = "ServiceMonitoring"
+	return out
+}
+
+func convertEndpointsFrom(in []v1alpha1.ScrapeEndpoint) []ScrapeEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := make([]ScrapeEndpoint, len(in))
+	for i, ep := range in {
+		out[i] = ScrapeEndpoint{
+			Port:     ep.Port,
+			Path:     ep.Path,
+			Scheme:   ep.Scheme,
+			Interval: ep.Interval,
+		}
+	}
+	return out
+}
+
+func convertEndpointsTo(in []ScrapeEndpoint) []v1alpha1.ScrapeEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := make([]v1alpha1.ScrapeEndpoint, len(in))
+	for i, ep := range in {
+		out[i] = v1alpha1.ScrapeEndpoint{
+			Port:     ep.Port,
+			Path:     ep.Path,
+			Scheme:   ep.Scheme,
+			Interval: ep.Interval,
+		}
+	}
+	return out
+}
+
+func convertTargetLabelsFrom(in v1alpha1.TargetLabels) TargetLabels {
+	return TargetLabels{
+		FromPod:     convertLabelMappingsFrom(in.FromPod),
+		FromService: convertLabelMappingsFrom(in.FromService),
+	}
+}
+
+func convertTargetLabelsTo(in TargetLabels) v1alpha1.TargetLabels {
+	return v1alpha1.TargetLabels{
+		FromPod:     convertLabelMappingsTo(in.FromPod),
+		FromService: convertLabelMappingsTo(in.FromService),
+	}
+}
+
+func convertLabelMappingsFrom(in []v1alpha1.LabelMapping) []LabelMapping {
+	if in == nil {
+		return nil
+	}
+	out := make([]LabelMapping, len(in))
+	for i, m := range in {
+		out[i] = LabelMapping{From: m.From, To: m.To}
+	}
+	return out
+}
+
+func convertLabelMappingsTo(in []LabelMapping) []v1alpha1.LabelMapping {
+	if in == nil {
+		return nil
+	}
+	out := make([]v1alpha1.LabelMapping, len(in))
+	for i, m := range in {
+		out[i] = v1alpha1.LabelMapping{From: m.From, To: m.To}
+	}
+	return out
+}
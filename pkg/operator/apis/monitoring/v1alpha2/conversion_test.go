@@ -0,0 +1,130 @@
+package v1alpha2
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/google/gpe-collector/pkg/operator/apis/monitoring/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func randString(r *rand.Rand, n int) string {
+	const letters = "abcdefghijklmnopqrstuvwxyz0123456789-"
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = letters[r.Intn(len(letters))]
+	}
+	return string(b)
+}
+
+func randEndpoint(r *rand.Rand) v1alpha1.ScrapeEndpoint {
+	ep := v1alpha1.ScrapeEndpoint{
+		Path:     "/" + randString(r, 6),
+		Interval: randString(r, 2) + "s",
+	}
+	if r.Intn(2) == 0 {
+		ep.Scheme = "http"
+	} else {
+		ep.Scheme = "https"
+	}
+	if r.Intn(2) == 0 {
+		ep.Port = intstr.FromInt(r.Intn(65535))
+	} else {
+		ep.Port = intstr.FromString(randString(r, 6))
+	}
+	return ep
+}
+
+func randLabelMappings(r *rand.Rand) []v1alpha1.LabelMapping {
+	n := r.Intn(4)
+	if n == 0 {
+		return nil
+	}
+	out := make([]v1alpha1.LabelMapping, n)
+	for i := range out {
+		out[i] = v1alpha1.LabelMapping{From: randString(r, 5), To: randString(r, 5)}
+	}
+	return out
+}
+
+// fuzzedPodMonitoring wraps a v1alpha1.PodMonitoring so testing/quick can
+// generate randomized instances without a Generator for every nested
+// apimachinery type.
+type fuzzedPodMonitoring struct {
+	pm v1alpha1.PodMonitoring
+}
+
+// Generate implements quick.Generator.
+func (fuzzedPodMonitoring) Generate(r *rand.Rand, size int) reflect.Value {
+	pm := v1alpha1.PodMonitoring{}
+	// ConvertPodMonitoringTo always stamps the v1alpha1 TypeMeta onto its
+	// output, matching how a real object decoded off the wire carries it;
+	// seed it here so DeepEqual isn't comparing against a zero value that
+	// never round-trips in practice.
+	pm.TypeMeta = metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "PodMonitoring"}
+	pm.Name = randString(r, 8)
+	pm.Namespace = randString(r, 8)
+	pm.ResourceVersion = randString(r, 4)
+
+	pm.Spec.Endpoints = make([]v1alpha1.ScrapeEndpoint, r.Intn(3)+1)
+	for i := range pm.Spec.Endpoints {
+		pm.Spec.Endpoints[i] = randEndpoint(r)
+	}
+	pm.Spec.TargetLabels = v1alpha1.TargetLabels{FromPod: randLabelMappings(r)}
+
+	return reflect.ValueOf(fuzzedPodMonitoring{pm: pm})
+}
+
+// TestPodMonitoringRoundTrip fuzzes v1alpha1 PodMonitoring objects and
+// checks that converting to v1alpha2 and back loses no field.
+func TestPodMonitoringRoundTrip(t *testing.T) {
+	roundTrip := func(f fuzzedPodMonitoring) bool {
+		out := ConvertPodMonitoringTo(ConvertPodMonitoringFrom(&f.pm))
+		return reflect.DeepEqual(&f.pm, out)
+	}
+	if err := quick.Check(roundTrip, &quick.Config{MaxCount: 200}); err != nil {
+		t.Error(err)
+	}
+}
+
+// fuzzedServiceMonitoring wraps a v1alpha1.ServiceMonitoring, analogous to
+// fuzzedPodMonitoring.
+type fuzzedServiceMonitoring struct {
+	sm v1alpha1.ServiceMonitoring
+}
+
+// Generate implements quick.Generator.
+func (fuzzedServiceMonitoring) Generate(r *rand.Rand, size int) reflect.Value {
+	sm := v1alpha1.ServiceMonitoring{}
+	// See the matching comment in fuzzedPodMonitoring.Generate.
+	sm.TypeMeta = metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "ServiceMonitoring"}
+	sm.Name = randString(r, 8)
+	sm.Namespace = randString(r, 8)
+	sm.ResourceVersion = randString(r, 4)
+
+	sm.Spec.Endpoints = make([]v1alpha1.ScrapeEndpoint, r.Intn(3)+1)
+	for i := range sm.Spec.Endpoints {
+		sm.Spec.Endpoints[i] = randEndpoint(r)
+	}
+	sm.Spec.TargetLabels = v1alpha1.TargetLabels{
+		FromPod:     randLabelMappings(r),
+		FromService: randLabelMappings(r),
+	}
+
+	return reflect.ValueOf(fuzzedServiceMonitoring{sm: sm})
+}
+
+// TestServiceMonitoringRoundTrip fuzzes v1alpha1 ServiceMonitoring objects
+// and checks that converting to v1alpha2 and back loses no field.
+func TestServiceMonitoringRoundTrip(t *testing.T) {
+	roundTrip := func(f fuzzedServiceMonitoring) bool {
+		out := ConvertServiceMonitoringTo(ConvertServiceMonitoringFrom(&f.sm))
+		return reflect.DeepEqual(&f.sm, out)
+	}
+	if err := quick.Check(roundTrip, &quick.Config{MaxCount: 200}); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,35 @@
+// Package v1alpha2 contains the monitoring.googleapis.com/v1alpha2 API
+// types: PodMonitoring and ServiceMonitoring, extended from v1alpha1 with
+// per-endpoint authentication and static targets. A conversion webhook
+// translates between this version and v1alpha1 so existing v1alpha1 users
+// keep working unchanged.
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/google/gpe-collector/pkg/operator/apis/monitoring/v1alpha1"
+)
+
+// SchemeGroupVersion is the identifier for this API's group and version.
+var SchemeGroupVersion = schema.GroupVersion{Group: v1alpha1.GroupName, Version: "v1alpha2"}
+
+var (
+	// SchemeBuilder collects functions that add types to a scheme.
+	SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
+	// AddToScheme applies all the stored functions to the given scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
+
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(SchemeGroupVersion,
+		&PodMonitoring{},
+		&PodMonitoringList{},
+		&ServiceMonitoring{},
+		&ServiceMonitoringList{},
+	)
+	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
+	return nil
+}
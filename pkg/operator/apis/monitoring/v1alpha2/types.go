@@ -0,0 +1,142 @@
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PodMonitoring defines a set of Prometheus scrape targets discovered from
+// pods matching a label selector, plus any StaticTargets declared directly.
+type PodMonitoring struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PodMonitoringSpec `json:"spec"`
+}
+
+// PodMonitoringSpec specifies how to discover and scrape PodMonitoring
+// targets.
+type PodMonitoringSpec struct {
+	// Selector selects the pods this resource discovers targets from.
+	Selector metav1.LabelSelector `json:"selector"`
+	// Endpoints specifies the scrape endpoints exposed by the selected pods.
+	Endpoints []ScrapeEndpoint `json:"endpoints"`
+	// TargetLabels configures labels copied onto scraped targets.
+	TargetLabels TargetLabels `json:"targetLabels,omitempty"`
+	// StaticTargets declares scrape targets outside of pod discovery. New in
+	// v1alpha2.
+	StaticTargets []StaticTargetGroup `json:"staticTargets,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PodMonitoringList is a list of PodMonitorings.
+type PodMonitoringList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []PodMonitoring `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServiceMonitoring defines a set of Prometheus scrape targets discovered
+// from the endpoints of services matching a label selector, plus any
+// StaticTargets declared directly.
+type ServiceMonitoring struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ServiceMonitoringSpec `json:"spec"`
+}
+
+// ServiceMonitoringSpec specifies how to discover and scrape
+// ServiceMonitoring targets.
+type ServiceMonitoringSpec struct {
+	// Selector selects the services this resource discovers targets from.
+	Selector metav1.LabelSelector `json:"selector"`
+	// Endpoints specifies the scrape endpoints exposed by the selected
+	// services.
+	Endpoints []ScrapeEndpoint `json:"endpoints"`
+	// TargetLabels configures labels copied onto scraped targets.
+	TargetLabels TargetLabels `json:"targetLabels,omitempty"`
+	// StaticTargets declares scrape targets outside of service discovery.
+	// New in v1alpha2.
+	StaticTargets []StaticTargetGroup `json:"staticTargets,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServiceMonitoringList is a list of ServiceMonitorings.
+type ServiceMonitoringList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ServiceMonitoring `json:"items"`
+}
+
+// ScrapeEndpoint specifies a Prometheus scrape endpoint exposed by a target.
+type ScrapeEndpoint struct {
+	// Port on the target to scrape, by name or number.
+	Port intstr.IntOrString `json:"port"`
+	// Path from which to scrape metrics. Defaults to "/metrics".
+	Path string `json:"path,omitempty"`
+	// Scheme to scrape over, "http" or "https". Defaults to "http".
+	Scheme string `json:"scheme,omitempty"`
+	// Interval at which the endpoint is scraped.
+	Interval string `json:"interval,omitempty"`
+	// Auth configures the credentials presented when scraping the endpoint.
+	// New in v1alpha2.
+	Auth *EndpointAuth `json:"auth,omitempty"`
+}
+
+// EndpointAuth configures authentication credentials presented when scraping
+// an endpoint.
+type EndpointAuth struct {
+	// BasicAuth, if set, authenticates via HTTP Basic auth.
+	BasicAuth *BasicAuth `json:"basicAuth,omitempty"`
+	// BearerTokenSecret, if set, names a Secret key whose value is presented
+	// as a bearer token in the Authorization header.
+	BearerTokenSecret *corev1.SecretKeySelector `json:"bearerTokenSecret,omitempty"`
+}
+
+// BasicAuth holds HTTP Basic authentication credentials.
+type BasicAuth struct {
+	// Username to present.
+	Username string `json:"username,omitempty"`
+	// PasswordSecret names a Secret key holding the password to present.
+	PasswordSecret *corev1.SecretKeySelector `json:"passwordSecret,omitempty"`
+}
+
+// StaticTargetGroup declares a fixed list of scrape targets alongside the
+// resource's discovered pods/services.
+type StaticTargetGroup struct {
+	// Targets are host:port addresses to scrape.
+	Targets []string `json:"targets"`
+	// Labels are attached to every target in the group.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// TargetLabels configures labels copied from the discovered pod/service onto
+// the scraped target.
+type TargetLabels struct {
+	// FromPod copies labels from the discovered pod onto the target.
+	FromPod []LabelMapping `json:"fromPod,omitempty"`
+	// FromService copies labels from the discovered service onto the
+	// target. Only valid on ServiceMonitoring.
+	FromService []LabelMapping `json:"fromService,omitempty"`
+}
+
+// LabelMapping specifies how a label on the discovered resource is copied to
+// a label on the scraped target.
+type LabelMapping struct {
+	// From is the resource label to copy.
+	From string `json:"from"`
+	// To is the target label to populate. Defaults to From.
+	To string `json:"to,omitempty"`
+}
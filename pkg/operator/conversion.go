@@ -0,0 +1,292 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/google/gpe-collector/pkg/operator/apis/monitoring/v1alpha1"
+	"github.com/google/gpe-collector/pkg/operator/apis/monitoring/v1alpha2"
+	"github.com/pkg/errors"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const convertPath = "/convert"
+
+// serveConversion returns a handler for the /convert endpoint the
+// kube-apiserver calls to convert PodMonitoring/ServiceMonitoring objects
+// between API versions, per the apiextensions.k8s.io/v1 conversion webhook
+// contract.
+func (a *AdmissionServer) serveConversion() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			a.logger.Error(err, "reading conversion review body")
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var review apiextv1.ConversionReview
+		if err := json.Unmarshal(data, &review); err != nil {
+			a.logger.Error(err, "decoding conversion review")
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		review.Response = convertObjects(review.Request)
+		review.Response.UID = review.Request.UID
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(review); err != nil {
+			a.logger.Error(err, "encoding conversion review")
+		}
+	}
+}
+
+// convertObjects converts every object in req to req.DesiredAPIVersion,
+// preserving metadata.resourceVersion and any fields the target version's
+// Go structs don't recognize, via mergeUnknownFields.
+func convertObjects(req *apiextv1.ConversionRequest) *apiextv1.ConversionResponse {
+	resp := &apiextv1.ConversionResponse{
+		Result: metav1.Status{Status: metav1.StatusSuccess},
+	}
+	for _, obj := range req.Objects {
+		converted, err := convertObject(obj, req.DesiredAPIVersion)
+		if err != nil {
+			return &apiextv1.ConversionResponse{
+				Result: metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: err.Error(),
+				},
+			}
+		}
+		resp.ConvertedObjects = append(resp.ConvertedObjects, converted)
+	}
+	return resp
+}
+
+func convertObject(obj runtime.RawExtension, desiredAPIVersion string) (runtime.RawExtension, error) {
+	var typeMeta metav1.TypeMeta
+	if err := json.Unmarshal(obj.Raw, &typeMeta); err != nil {
+		return runtime.RawExtension{}, errors.Wrap(err, "decoding object type meta")
+	}
+
+	switch typeMeta.Kind {
+	case "PodMonitoring":
+		return convertPodMonitoring(obj.Raw, typeMeta.APIVersion, desiredAPIVersion)
+	case "ServiceMonitoring":
+		return convertServiceMonitoring(obj.Raw, typeMeta.APIVersion, desiredAPIVersion)
+	default:
+		return runtime.RawExtension{}, fmt.Errorf("unsupported kind %q", typeMeta.Kind)
+	}
+}
+
+func convertPodMonitoring(raw []byte, from, to string) (runtime.RawExtension, error) {
+	if from == to {
+		return runtime.RawExtension{Raw: raw}, nil
+	}
+	switch {
+	case from == v1alpha1.SchemeGroupVersion.String() && to == v1alpha2.SchemeGroupVersion.String():
+		in := &v1alpha1.PodMonitoring{}
+		if err := json.Unmarshal(raw, in); err != nil {
+			return runtime.RawExtension{}, errors.Wrap(err, "decoding v1alpha1 PodMonitoring")
+		}
+		b, err := json.Marshal(v1alpha2.ConvertPodMonitoringFrom(in))
+		if err != nil {
+			return runtime.RawExtension{}, errors.Wrap(err, "encoding v1alpha2 PodMonitoring")
+		}
+		merged, err := mergeUnknownFields(raw, b, reflect.TypeOf(v1alpha1.PodMonitoring{}))
+		return runtime.RawExtension{Raw: merged}, errors.Wrap(err, "merging unknown fields into v1alpha2 PodMonitoring")
+	case from == v1alpha2.SchemeGroupVersion.String() && to == v1alpha1.SchemeGroupVersion.String():
+		in := &v1alpha2.PodMonitoring{}
+		if err := json.Unmarshal(raw, in); err != nil {
+			return runtime.RawExtension{}, errors.Wrap(err, "decoding v1alpha2 PodMonitoring")
+		}
+		b, err := json.Marshal(v1alpha2.ConvertPodMonitoringTo(in))
+		if err != nil {
+			return runtime.RawExtension{}, errors.Wrap(err, "encoding v1alpha1 PodMonitoring")
+		}
+		merged, err := mergeUnknownFields(raw, b, reflect.TypeOf(v1alpha2.PodMonitoring{}))
+		return runtime.RawExtension{Raw: merged}, errors.Wrap(err, "merging unknown fields into v1alpha1 PodMonitoring")
+	default:
+		return runtime.RawExtension{}, fmt.Errorf("unsupported conversion from %q to %q", from, to)
+	}
+}
+
+func convertServiceMonitoring(raw []byte, from, to string) (runtime.RawExtension, error) {
+	if from == to {
+		return runtime.RawExtension{Raw: raw}, nil
+	}
+	switch {
+	case from == v1alpha1.SchemeGroupVersion.String() && to == v1alpha2.SchemeGroupVersion.String():
+		in := &v1alpha1.ServiceMonitoring{}
+		if err := json.Unmarshal(raw, in); err != nil {
+			return runtime.RawExtension{}, errors.Wrap(err, "decoding v1alpha1 ServiceMonitoring")
+		}
+		b, err := json.Marshal(v1alpha2.ConvertServiceMonitoringFrom(in))
+		if err != nil {
+			return runtime.RawExtension{}, errors.Wrap(err, "encoding v1alpha2 ServiceMonitoring")
+		}
+		merged, err := mergeUnknownFields(raw, b, reflect.TypeOf(v1alpha1.ServiceMonitoring{}))
+		return runtime.RawExtension{Raw: merged}, errors.Wrap(err, "merging unknown fields into v1alpha2 ServiceMonitoring")
+	case from == v1alpha2.SchemeGroupVersion.String() && to == v1alpha1.SchemeGroupVersion.String():
+		in := &v1alpha2.ServiceMonitoring{}
+		if err := json.Unmarshal(raw, in); err != nil {
+			return runtime.RawExtension{}, errors.Wrap(err, "decoding v1alpha2 ServiceMonitoring")
+		}
+		b, err := json.Marshal(v1alpha2.ConvertServiceMonitoringTo(in))
+		if err != nil {
+			return runtime.RawExtension{}, errors.Wrap(err, "encoding v1alpha1 ServiceMonitoring")
+		}
+		merged, err := mergeUnknownFields(raw, b, reflect.TypeOf(v1alpha2.ServiceMonitoring{}))
+		return runtime.RawExtension{Raw: merged}, errors.Wrap(err, "merging unknown fields into v1alpha1 ServiceMonitoring")
+	default:
+		return runtime.RawExtension{}, fmt.Errorf("unsupported conversion from %q to %q", from, to)
+	}
+}
+
+// mergeUnknownFields folds any field present in the original object but
+// genuinely unrecognized by source's own JSON schema back onto the
+// converted result, recursing into nested objects. Typed conversion
+// unmarshals original into source's Go structs, which silently drop fields
+// they don't recognize (e.g. ones a newer/older controller added, or data
+// from outside this API group entirely); this restores those so a round
+// trip through /convert doesn't lose data neither version modeled.
+//
+// A field source's schema does recognize but that ConvertTo/ConvertFrom
+// deliberately dropped because the other version has no equivalent (e.g.
+// v1alpha2's spec.staticTargets, absent from v1alpha1) is NOT restored:
+// source's struct tags are the source of truth for what that version
+// supports, so reintroducing a field it modeled and the converter chose to
+// drop would reverse an intentional, lossy downgrade.
+//
+// Only JSON objects backed by a Go struct are merged field-by-field; an
+// array, scalar, or a Go map (e.g. ObjectMeta.Labels) present in both
+// original and converted is taken from converted as-is, since neither has a
+// fixed field set to diff against (arrays have no positional
+// correspondence to merge by, and a map's "schema" is whatever keys happen
+// to be present).
+func mergeUnknownFields(original, converted []byte, source reflect.Type) ([]byte, error) {
+	var orig, conv map[string]interface{}
+	if err := json.Unmarshal(original, &orig); err != nil {
+		return nil, errors.Wrap(err, "decoding original object")
+	}
+	if err := json.Unmarshal(converted, &conv); err != nil {
+		return nil, errors.Wrap(err, "decoding converted object")
+	}
+	return json.Marshal(mergeUnknownFieldsMap(orig, conv, source))
+}
+
+func mergeUnknownFieldsMap(orig, conv map[string]interface{}, source reflect.Type) map[string]interface{} {
+	known := jsonFieldTypes(source)
+
+	merged := make(map[string]interface{}, len(conv))
+	for k, v := range conv {
+		merged[k] = v
+	}
+	for k, origVal := range orig {
+		fieldType, isKnown := known[k]
+		if !isKnown {
+			// source's own schema has no field for k at all: genuinely
+			// unknown to either version, so carry it through wholesale.
+			merged[k] = origVal
+			continue
+		}
+		convVal, ok := merged[k]
+		if !ok {
+			// source recognizes k but the converted side dropped it
+			// entirely (e.g. no equivalent field in the other version):
+			// an intentional, lossy drop, not something to restore.
+			continue
+		}
+		origChild, origIsObj := origVal.(map[string]interface{})
+		convChild, convIsObj := convVal.(map[string]interface{})
+		if origIsObj && convIsObj && isStructType(fieldType) {
+			merged[k] = mergeUnknownFieldsMap(origChild, convChild, fieldType)
+		}
+	}
+	return merged
+}
+
+// isStructType reports whether t (after dereferencing pointers) is a Go
+// struct, i.e. has a fixed, named field set that jsonFieldTypes can walk.
+// Go maps serialize to JSON objects too, but their keys aren't a schema to
+// diff against, so they're excluded.
+func isStructType(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+// jsonFieldTypes returns the JSON field names t's struct recognizes, mapped
+// to each field's Go type, following inline/embedded fields (e.g.
+// metav1.TypeMeta) as if their fields belonged to t directly.
+func jsonFieldTypes(t reflect.Type) map[string]reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	fields := map[string]reflect.Type{}
+	if t.Kind() != reflect.Struct {
+		return fields
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if name == "" {
+			// Embedded struct with no explicit JSON name (e.g.
+			// metav1.TypeMeta `json:",inline"`): its fields belong to t.
+			for k, v := range jsonFieldTypes(f.Type) {
+				fields[k] = v
+			}
+			continue
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// patchCRDConversionWebhook points crdName's spec.conversion.webhook at this
+// operator's /convert endpoint, using caBundle to verify the serving
+// certificate.
+func (o *Operator) patchCRDConversionWebhook(ctx context.Context, crdName string, caBundle []byte) error {
+	client := o.apiextClient.ApiextensionsV1().CustomResourceDefinitions()
+
+	crd, err := client.Get(ctx, crdName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		// The CRD is installed separately from the operator binary; nothing
+		// to patch until it exists.
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "get CRD")
+	}
+
+	path := convertPath
+	crd.Spec.Conversion = &apiextv1.CustomResourceConversion{
+		Strategy: apiextv1.WebhookConverter,
+		Webhook: &apiextv1.WebhookConversion{
+			ConversionReviewVersions: []string{"v1"},
+			ClientConfig: &apiextv1.WebhookClientConfig{
+				CABundle: caBundle,
+				Service: &apiextv1.ServiceReference{
+					Namespace: o.opts.Namespace,
+					Name:      webhookServiceName,
+					Path:      &path,
+				},
+			},
+		},
+	}
+	_, err = client.Update(ctx, crd, metav1.UpdateOptions{})
+	return errors.Wrap(err, "update CRD")
+}
@@ -0,0 +1,102 @@
+package operator
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/google/gpe-collector/pkg/operator/apis/monitoring/v1alpha1"
+	"github.com/google/gpe-collector/pkg/operator/apis/monitoring/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// TestConvertPodMonitoringDropsVersionSpecificFields checks that converting
+// a v1alpha2 PodMonitoring carrying fields v1alpha1 has no equivalent for
+// (spec.staticTargets, endpoints[].auth) down to v1alpha1 actually drops
+// them from the merged result, rather than mergeUnknownFields restoring
+// them from the original bytes because the merge only recurses into JSON
+// objects and treated them as merge-worthy "unknown" fields.
+func TestConvertPodMonitoringDropsVersionSpecificFields(t *testing.T) {
+	pm := &v1alpha2.PodMonitoring{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha2.SchemeGroupVersion.String(), Kind: "PodMonitoring"},
+		ObjectMeta: metav1.ObjectMeta{Name: "pm", Namespace: "ns"},
+		Spec: v1alpha2.PodMonitoringSpec{
+			Endpoints: []v1alpha2.ScrapeEndpoint{{
+				Port:     intstr.FromString("metrics"),
+				Interval: "30s",
+				Auth:     &v1alpha2.EndpointAuth{BasicAuth: &v1alpha2.BasicAuth{Username: "admin"}},
+			}},
+			StaticTargets: []v1alpha2.StaticTargetGroup{{Targets: []string{"10.0.0.1:9090"}}},
+		},
+	}
+	raw, err := json.Marshal(pm)
+	if err != nil {
+		t.Fatalf("marshal v1alpha2 PodMonitoring: %s", err)
+	}
+
+	out, err := convertPodMonitoring(raw, v1alpha2.SchemeGroupVersion.String(), v1alpha1.SchemeGroupVersion.String())
+	if err != nil {
+		t.Fatalf("convertPodMonitoring: %s", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(out.Raw, &got); err != nil {
+		t.Fatalf("unmarshal converted object: %s", err)
+	}
+	spec, ok := got["spec"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("converted object has no spec: %v", got)
+	}
+	if _, ok := spec["staticTargets"]; ok {
+		t.Errorf("converted v1alpha1 object still carries spec.staticTargets: %v", spec)
+	}
+
+	endpoints, ok := spec["endpoints"].([]interface{})
+	if !ok || len(endpoints) != 1 {
+		t.Fatalf("converted object has unexpected endpoints: %v", spec["endpoints"])
+	}
+	ep, ok := endpoints[0].(map[string]interface{})
+	if !ok {
+		t.Fatalf("endpoint[0] is not an object: %v", endpoints[0])
+	}
+	if _, ok := ep["auth"]; ok {
+		t.Errorf("converted v1alpha1 endpoint still carries auth: %v", ep)
+	}
+
+	// Sanity-check the typed decode also comes back clean.
+	var pm1 v1alpha1.PodMonitoring
+	if err := json.Unmarshal(out.Raw, &pm1); err != nil {
+		t.Fatalf("unmarshal as v1alpha1.PodMonitoring: %s", err)
+	}
+	if pm1.Spec.Endpoints[0].Port != intstr.FromString("metrics") {
+		t.Errorf("port = %v, want metrics", pm1.Spec.Endpoints[0].Port)
+	}
+}
+
+// TestMergeUnknownFieldsMapDoesNotDiffMaps checks that a Go map field (e.g.
+// ObjectMeta.Annotations) is taken from the converted side as-is rather than
+// merged key-by-key: a map has no fixed schema to diff the original against,
+// so treating it like a struct would restore keys the converter may have
+// intentionally dropped or rewritten.
+func TestMergeUnknownFieldsMapDoesNotDiffMaps(t *testing.T) {
+	type withMap struct {
+		Annotations map[string]string `json:"annotations,omitempty"`
+	}
+
+	orig := map[string]interface{}{"annotations": map[string]interface{}{"old": "value"}}
+	conv := map[string]interface{}{"annotations": map[string]interface{}{"new": "value"}}
+
+	merged := mergeUnknownFieldsMap(orig, conv, reflect.TypeOf(withMap{}))
+
+	annotations, ok := merged["annotations"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("merged annotations is not an object: %v", merged["annotations"])
+	}
+	if _, ok := annotations["old"]; ok {
+		t.Errorf("merged annotations restored %q from the original map: %v", "old", annotations)
+	}
+	if _, ok := annotations["new"]; !ok {
+		t.Errorf("merged annotations dropped the converted side's key: %v", annotations)
+	}
+}
@@ -0,0 +1,99 @@
+// Package operator implements the Prometheus Engine operator, which manages
+// the collection pipeline and associated admission webhooks for a cluster.
+package operator
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/versioned"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// DefaultNamespace is the namespace in which the operator manages its
+	// resources if not overridden by the --namespace flag.
+	DefaultNamespace = "gpe-system"
+
+	// ImageCollector is the default container image used for the Prometheus
+	// collector deployed by the operator.
+	ImageCollector = "gcr.io/gpe-project/collector:latest"
+	// ImageConfigReloader is the default container image used for the
+	// config-reloader sidecar deployed alongside the collector.
+	ImageConfigReloader = "gcr.io/gpe-project/config-reloader:latest"
+)
+
+// Options bundles the configurable settings of the Operator.
+type Options struct {
+	// Namespace in which the operator manages its resources.
+	Namespace string
+	// ImageCollector is the container image for the collector.
+	ImageCollector string
+	// ImageConfigReloader is the container image for the config reloader.
+	ImageConfigReloader string
+	// PriorityClass is the priority class at which the collector pods are run.
+	PriorityClass string
+	// CloudMonitoringEndpoint is an override for the Cloud Monitoring
+	// endpoint to use for all collectors.
+	CloudMonitoringEndpoint string
+	// CASelfSign determines whether the operator self-signs the webhook CA
+	// and serving certificate or has them provisioned externally.
+	CASelfSign bool
+	// TLSCertFile and TLSKeyFile are the serving certificate and key the
+	// admission server presents to the apiserver. They are only consulted
+	// when CASelfSign is false.
+	TLSCertFile string
+	TLSKeyFile  string
+	// ListenAddr is the address the admission webhook server listens on.
+	ListenAddr string
+	// ProbeListenAddr is the address the health/readiness probe server
+	// listens on. It is served over plain HTTP, separate from the TLS
+	// admission webhook port.
+	ProbeListenAddr string
+	// ShutdownTimeout bounds how long the admission server waits for
+	// in-flight requests to drain during a graceful shutdown.
+	ShutdownTimeout time.Duration
+	// Cluster is injected as the `cluster` target label on all scraped
+	// resources that don't already set it.
+	Cluster string
+	// Location is injected as the `location` target label on all scraped
+	// resources that don't already set it.
+	Location string
+}
+
+// Operator manages the Prometheus collection pipeline for a cluster,
+// including reconciling collector resources and serving admission webhooks.
+type Operator struct {
+	logger       logr.Logger
+	opts         Options
+	kubeClient   kubernetes.Interface
+	apiextClient apiextensionsclientset.Interface
+}
+
+// New instantiates a new Operator.
+func New(logger logr.Logger, cfg *rest.Config, opts Options) (*Operator, error) {
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "build Kubernetes clientset")
+	}
+	apiextClient, err := apiextensionsclientset.NewForConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "build apiextensions clientset")
+	}
+	return &Operator{
+		logger:       logger,
+		opts:         opts,
+		kubeClient:   client,
+		apiextClient: apiextClient,
+	}, nil
+}
+
+// Run starts the operator reconciliation loops and blocks until ctx is
+// canceled.
+func (o *Operator) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
@@ -0,0 +1,88 @@
+package operator
+
+import (
+	"fmt"
+
+	"github.com/google/gpe-collector/pkg/operator/apis/monitoring/v1alpha1"
+	"github.com/pkg/errors"
+	"github.com/prometheus/prometheus/pkg/relabel"
+	"gopkg.in/yaml.v2"
+)
+
+// scrapeConfig mirrors the subset of a Prometheus scrape_config block the
+// /dryrun/podmonitoring endpoint renders for a single PodMonitoring endpoint.
+type scrapeConfig struct {
+	JobName        string            `yaml:"job_name"`
+	ScrapeInterval string            `yaml:"scrape_interval,omitempty"`
+	MetricsPath    string            `yaml:"metrics_path,omitempty"`
+	Scheme         string            `yaml:"scheme,omitempty"`
+	RelabelConfigs []*relabel.Config `yaml:"relabel_configs,omitempty"`
+}
+
+// renderPodMonitoringScrapeConfig renders the Prometheus scrape_config(s),
+// one per endpoint, that the collector would generate for pm. This is
+// currently the only place that turns a PodMonitoring into a scrape_config:
+// the operator's reconciliation loop (Operator.Run) doesn't write a live
+// collector config yet, so /dryrun/podmonitoring is not a preview alongside
+// some other production path — it's the one path that exists. Once a
+// reconciler lands, it must call this same function (and pick up
+// staticTargetLabelRelabelConfigs with it) rather than reimplementing
+// rendering.
+func renderPodMonitoringScrapeConfig(pm *v1alpha1.PodMonitoring, opts Options) ([]byte, error) {
+	relabelConfigs, err := labelMappingRelabelConfigs(pm.Spec.TargetLabels.FromPod, podLabelPrefix)
+	if err != nil {
+		return nil, errors.Wrap(err, "building relabel configs")
+	}
+	relabelConfigs = append(relabelConfigs, staticTargetLabelRelabelConfigs(opts, pm.Spec.TargetLabels)...)
+
+	var configs []scrapeConfig
+	for i, ep := range pm.Spec.Endpoints {
+		configs = append(configs, scrapeConfig{
+			JobName:        fmt.Sprintf("PodMonitoring/%s/%s/%d", pm.Namespace, pm.Name, i),
+			ScrapeInterval: ep.Interval,
+			MetricsPath:    ep.Path,
+			Scheme:         ep.Scheme,
+			RelabelConfigs: relabelConfigs,
+		})
+	}
+	return yaml.Marshal(configs)
+}
+
+// staticTargetLabelRelabelConfigs returns relabel configs that stamp the
+// operator-wide cluster/location target labels from opts onto every target
+// that doesn't already map one in from the pod/service. Unlike
+// labelMappingRelabelConfigs, these carry a literal replacement value rather
+// than copying a source label, since PodMonitoring/ServiceMonitoring have no
+// field to request a literal value from Options.
+func staticTargetLabelRelabelConfigs(opts Options, tl v1alpha1.TargetLabels) []*relabel.Config {
+	has := func(to string) bool {
+		for _, m := range tl.FromPod {
+			if m.To == to {
+				return true
+			}
+		}
+		for _, m := range tl.FromService {
+			if m.To == to {
+				return true
+			}
+		}
+		return false
+	}
+
+	var cfgs []*relabel.Config
+	if opts.Cluster != "" && !has("cluster") {
+		cfgs = append(cfgs, &relabel.Config{
+			Action:      relabel.Replace,
+			TargetLabel: "cluster",
+			Replacement: opts.Cluster,
+		})
+	}
+	if opts.Location != "" && !has("location") {
+		cfgs = append(cfgs, &relabel.Config{
+			Action:      relabel.Replace,
+			TargetLabel: "location",
+			Replacement: opts.Location,
+		})
+	}
+	return cfgs
+}
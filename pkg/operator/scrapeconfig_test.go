@@ -0,0 +1,34 @@
+package operator
+
+import (
+	"testing"
+
+	"github.com/google/gpe-collector/pkg/operator/apis/monitoring/v1alpha1"
+)
+
+// TestStaticTargetLabelRelabelConfigsSkipsExisting checks that the
+// operator-wide cluster/location labels aren't stamped over a target that
+// already maps one in via FromPod/FromService, matching the "don't already
+// set it" behavior documented on Options.Cluster/Options.Location.
+func TestStaticTargetLabelRelabelConfigsSkipsExisting(t *testing.T) {
+	opts := Options{Cluster: "prod", Location: "us-east1"}
+
+	tl := v1alpha1.TargetLabels{
+		FromPod:     []v1alpha1.LabelMapping{{From: "gke_cluster", To: "cluster"}},
+		FromService: []v1alpha1.LabelMapping{{From: "gke_zone", To: "location"}},
+	}
+	if cfgs := staticTargetLabelRelabelConfigs(opts, tl); len(cfgs) != 0 {
+		t.Errorf("expected no static relabel configs when both labels are already mapped, got %v", cfgs)
+	}
+
+	empty := v1alpha1.TargetLabels{}
+	cfgs := staticTargetLabelRelabelConfigs(opts, empty)
+	if len(cfgs) != 2 {
+		t.Fatalf("expected 2 static relabel configs when neither label is mapped, got %d", len(cfgs))
+	}
+	for _, c := range cfgs {
+		if c.TargetLabel != "cluster" && c.TargetLabel != "location" {
+			t.Errorf("unexpected TargetLabel %q", c.TargetLabel)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package operator
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	healthzPath = "/healthz"
+	readyzPath  = "/readyz"
+
+	// DefaultShutdownTimeout bounds how long Run waits for in-flight
+	// admission requests to drain before the process exits, if
+	// Options.ShutdownTimeout isn't set.
+	DefaultShutdownTimeout = 10 * time.Second
+)
+
+// Run starts the admission webhook server and its health/readiness probe
+// server, and blocks until ctx is canceled. On cancellation, both servers
+// are drained via graceful shutdown, bounded by Options.ShutdownTimeout.
+func (a *AdmissionServer) Run(ctx context.Context) error {
+	errc := make(chan error, 2)
+
+	go func() {
+		if err := a.srv.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
+			errc <- err
+			return
+		}
+		errc <- nil
+	}()
+	go func() {
+		if err := a.probeSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errc <- err
+			return
+		}
+		errc <- nil
+	}()
+
+	select {
+	case err := <-errc:
+		// One of the servers exited on its own; shut the other down too.
+		a.shutdown()
+		return err
+	case <-ctx.Done():
+		a.shutdown()
+		// Wait for both ListenAndServe calls to return from Shutdown.
+		firstErr := <-errc
+		if err := <-errc; err != nil && firstErr == nil {
+			firstErr = err
+		}
+		return firstErr
+	}
+}
+
+func (a *AdmissionServer) shutdown() {
+	timeout := a.opts.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = DefaultShutdownTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := a.srv.Shutdown(ctx); err != nil {
+		a.logger.Error(err, "shutting down admission server")
+	}
+	if err := a.probeSrv.Shutdown(ctx); err != nil {
+		a.logger.Error(err, "shutting down probe server")
+	}
+}
+
+// serveHealthz reports whether the process is alive. It never depends on
+// cluster state, so it stays healthy even while the operator is catching up
+// after a restart.
+func (a *AdmissionServer) serveHealthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// serveReadyz reports whether the admission server is ready to receive
+// webhook traffic: its serving certificate is loaded and the webhook/CRD
+// resources it depends on have been reconciled.
+//
+// Informer-sync and leader-election readiness will be folded in here once
+// those subsystems exist in the operator.
+func (a *AdmissionServer) serveReadyz(w http.ResponseWriter, r *http.Request) {
+	if atomic.LoadInt32(&a.certReady) == 0 {
+		http.Error(w, "serving certificate not yet loaded", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,34 @@
+package operator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestServeHealthzAlwaysOK(t *testing.T) {
+	a := &AdmissionServer{}
+	w := httptest.NewRecorder()
+	a.serveHealthz(w, httptest.NewRequest(http.MethodGet, healthzPath, nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestServeReadyzReflectsCertReady(t *testing.T) {
+	a := &AdmissionServer{}
+
+	w := httptest.NewRecorder()
+	a.serveReadyz(w, httptest.NewRequest(http.MethodGet, readyzPath, nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status before cert ready = %d, want %d", w.Code, http.StatusServiceUnavailable)
+	}
+
+	atomic.StoreInt32(&a.certReady, 1)
+	w = httptest.NewRecorder()
+	a.serveReadyz(w, httptest.NewRequest(http.MethodGet, readyzPath, nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("status after cert ready = %d, want %d", w.Code, http.StatusOK)
+	}
+}
@@ -0,0 +1,61 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/gpe-collector/pkg/operator/admission"
+)
+
+// relabelMappingValidator checks that a Target's label mappings produce
+// valid relabel configs, which in turn compiles the regex each mapping is
+// built from.
+type relabelMappingValidator struct{}
+
+// Name implements admission.Validator.
+func (relabelMappingValidator) Name() string { return "relabel-mapping" }
+
+// Validate implements admission.Validator.
+func (relabelMappingValidator) Validate(_ context.Context, t *admission.Target) ([]admission.Warning, error) {
+	var errs []string
+
+	if _, err := labelMappingRelabelConfigs(t.TargetLabels.FromPod, podLabelPrefix); err != nil {
+		errs = append(errs, fmt.Sprintf("pod label mappings: %s", err))
+	}
+	if t.Kind == "ServiceMonitoring" {
+		if _, err := labelMappingRelabelConfigs(t.TargetLabels.FromService, serviceLabelPrefix); err != nil {
+			errs = append(errs, fmt.Sprintf("service label mappings: %s", err))
+		}
+	}
+	if len(errs) == 0 {
+		return nil, nil
+	}
+	return nil, errors.New(strings.Join(errs, "; "))
+}
+
+// newValidatorChain returns the chain of validators run against every
+// PodMonitoring/ServiceMonitoring admission request and by the
+// /dryrun/podmonitoring diagnostics endpoint.
+func newValidatorChain() *admission.Chain {
+	return admission.NewChain(
+		relabelMappingValidator{},
+		admission.PortExistenceValidator{},
+		admission.ScrapeIntervalBoundsValidator{},
+		admission.EndpointSchemeValidator{},
+	)
+}
+
+// warningStrings converts validator warnings to the plain strings expected
+// by AdmissionResponse.Warnings.
+func warningStrings(warnings []admission.Warning) []string {
+	if len(warnings) == 0 {
+		return nil
+	}
+	out := make([]string, len(warnings))
+	for i, w := range warnings {
+		out[i] = string(w)
+	}
+	return out
+}
@@ -0,0 +1,225 @@
+package operator
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// webhookServiceName is the name of the Kubernetes Service in front of the
+// admission server that the apiserver dials into.
+const webhookServiceName = "gpe-operator"
+
+var webhookFailurePolicy = admissionregistrationv1.Fail
+var webhookSideEffects = admissionregistrationv1.SideEffectClassNone
+var webhookScope = admissionregistrationv1.NamespacedScope
+
+// ensureCABundle returns the PEM-encoded CA bundle used to verify the
+// admission server's serving certificate, plus the serving certificate
+// itself. If opts.CASelfSign is set, the operator generates its own CA and
+// signs a leaf serving certificate with it; otherwise the serving
+// certificate is loaded from opts.TLSCertFile/TLSKeyFile and no bundle is
+// returned, since the kube-apiserver's own CA is trusted implicitly.
+func (o *Operator) ensureCABundle(ctx context.Context) ([]byte, tls.Certificate, error) {
+	if !o.opts.CASelfSign {
+		cert, err := tls.LoadX509KeyPair(o.opts.TLSCertFile, o.opts.TLSKeyFile)
+		if err != nil {
+			return nil, tls.Certificate{}, errors.Wrap(err, "load serving certificate")
+		}
+		return nil, cert, nil
+	}
+
+	caKey, caCertDER, err := selfSignedCA()
+	if err != nil {
+		return nil, tls.Certificate{}, errors.Wrap(err, "self-sign CA certificate")
+	}
+	servingCert, err := signServingCertificate(caKey, caCertDER, o.webhookDNSNames())
+	if err != nil {
+		return nil, tls.Certificate{}, errors.Wrap(err, "sign serving certificate")
+	}
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caCertDER})
+	return caBundle, servingCert, nil
+}
+
+// webhookDNSNames returns the DNS names the apiserver may use to dial the
+// admission server's Service; the serving certificate must cover all of
+// them.
+func (o *Operator) webhookDNSNames() []string {
+	return []string{
+		webhookServiceName,
+		fmt.Sprintf("%s.%s", webhookServiceName, o.opts.Namespace),
+		fmt.Sprintf("%s.%s.svc", webhookServiceName, o.opts.Namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", webhookServiceName, o.opts.Namespace),
+	}
+}
+
+// selfSignedCA generates a self-signed CA key pair, returning the key and
+// its DER-encoded certificate.
+func selfSignedCA() (*rsa.PrivateKey, []byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "generate CA key")
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: webhookServiceName + "-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(10 * 365 * 24 * time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, der, nil
+}
+
+// signServingCertificate generates a leaf certificate for dnsNames, signed
+// by the CA identified by caKey/caCertDER, ready to present on a TLS
+// listener.
+func signServingCertificate(caKey *rsa.PrivateKey, caCertDER []byte, dnsNames []string) (tls.Certificate, error) {
+	caCert, err := x509.ParseCertificate(caCertDER)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrap(err, "parse CA certificate")
+	}
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrap(err, "generate serving key")
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(10 * 365 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     dnsNames,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrap(err, "sign serving certificate")
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return tls.X509KeyPair(certPEM, keyPEM)
+}
+
+// upsertValidatingWebhookConfig creates or updates the ValidatingWebhookConfiguration
+// that routes PodMonitoring/ServiceMonitoring admission requests to this server.
+func (o *Operator) upsertValidatingWebhookConfig(ctx context.Context, caBundle []byte) error {
+	cfg := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: validatingWebhookConfigName},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{
+			o.webhookFor("podmonitorings.validation", validatePodMonitoringPath, []string{"podmonitorings"}, caBundle),
+			o.webhookFor("servicemonitorings.validation", validateServiceMonitoringPath, []string{"servicemonitorings"}, caBundle),
+		},
+	}
+	client := o.kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations()
+
+	if _, err := client.Create(ctx, cfg, metav1.CreateOptions{}); apierrors.IsAlreadyExists(err) {
+		existing, err := client.Get(ctx, cfg.Name, metav1.GetOptions{})
+		if err != nil {
+			return errors.Wrap(err, "get existing validating webhook configuration")
+		}
+		cfg.ResourceVersion = existing.ResourceVersion
+		_, err = client.Update(ctx, cfg, metav1.UpdateOptions{})
+		return errors.Wrap(err, "update validating webhook configuration")
+	} else if err != nil {
+		return errors.Wrap(err, "create validating webhook configuration")
+	}
+	return nil
+}
+
+// upsertMutatingWebhookConfig creates or updates the MutatingWebhookConfiguration
+// that routes PodMonitoring/ServiceMonitoring admission requests to this server
+// for defaulting.
+func (o *Operator) upsertMutatingWebhookConfig(ctx context.Context, caBundle []byte) error {
+	cfg := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: mutatingWebhookConfigName},
+		Webhooks: []admissionregistrationv1.MutatingWebhook{
+			o.mutatingWebhookFor("podmonitorings.mutation", mutatePodMonitoringPath, []string{"podmonitorings"}, caBundle),
+			o.mutatingWebhookFor("servicemonitorings.mutation", mutateServiceMonitoringPath, []string{"servicemonitorings"}, caBundle),
+		},
+	}
+	client := o.kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations()
+
+	if _, err := client.Create(ctx, cfg, metav1.CreateOptions{}); apierrors.IsAlreadyExists(err) {
+		existing, err := client.Get(ctx, cfg.Name, metav1.GetOptions{})
+		if err != nil {
+			return errors.Wrap(err, "get existing mutating webhook configuration")
+		}
+		cfg.ResourceVersion = existing.ResourceVersion
+		_, err = client.Update(ctx, cfg, metav1.UpdateOptions{})
+		return errors.Wrap(err, "update mutating webhook configuration")
+	} else if err != nil {
+		return errors.Wrap(err, "create mutating webhook configuration")
+	}
+	return nil
+}
+
+// webhookFor builds the ValidatingWebhook serving path at the operator's
+// service, scoped to the given resources so the apiserver only dispatches
+// requests for those resources to it; the admit handler behind path rejects
+// any other resource outright.
+func (o *Operator) webhookFor(name, path string, resources []string, caBundle []byte) admissionregistrationv1.ValidatingWebhook {
+	return admissionregistrationv1.ValidatingWebhook{
+		Name:                    name + ".gpe.gke.io",
+		AdmissionReviewVersions: []string{"v1"},
+		SideEffects:             &webhookSideEffects,
+		FailurePolicy:           &webhookFailurePolicy,
+		ClientConfig:            o.webhookClientConfig(path, caBundle),
+		Rules: []admissionregistrationv1.RuleWithOperations{{
+			Operations: []admissionregistrationv1.OperationType{
+				admissionregistrationv1.Create, admissionregistrationv1.Update,
+			},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{"monitoring.googleapis.com"},
+				APIVersions: []string{"v1alpha1"},
+				Resources:   resources,
+				Scope:       &webhookScope,
+			},
+		}},
+	}
+}
+
+// mutatingWebhookFor builds the MutatingWebhook serving path at the
+// operator's service, analogous to webhookFor.
+func (o *Operator) mutatingWebhookFor(name, path string, resources []string, caBundle []byte) admissionregistrationv1.MutatingWebhook {
+	v := o.webhookFor(name, path, resources, caBundle)
+	return admissionregistrationv1.MutatingWebhook{
+		Name:                    v.Name,
+		AdmissionReviewVersions: v.AdmissionReviewVersions,
+		SideEffects:             v.SideEffects,
+		FailurePolicy:           v.FailurePolicy,
+		ClientConfig:            v.ClientConfig,
+		Rules:                   v.Rules,
+	}
+}
+
+// webhookClientConfig returns the client config the apiserver uses to reach
+// this operator's admission server for the given HTTP path.
+func (o *Operator) webhookClientConfig(path string, caBundle []byte) admissionregistrationv1.WebhookClientConfig {
+	svcPath := path
+	return admissionregistrationv1.WebhookClientConfig{
+		CABundle: caBundle,
+		Service: &admissionregistrationv1.ServiceReference{
+			Namespace: o.opts.Namespace,
+			Name:      webhookServiceName,
+			Path:      &svcPath,
+		},
+	}
+}
@@ -0,0 +1,30 @@
+package operator
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestWebhookForScopesRules checks that each webhook entry's Rules only
+// cover the resource it was built for, so the apiserver doesn't dispatch a
+// PodMonitoring request to the ServiceMonitoring webhook (or vice versa),
+// which would trip admitPodMonitoring/admitServiceMonitoring's resource
+// check and reject the request under FailurePolicy: Fail.
+func TestWebhookForScopesRules(t *testing.T) {
+	o := &Operator{opts: Options{Namespace: "gpe-system"}}
+
+	pod := o.webhookFor("podmonitorings.validation", validatePodMonitoringPath, []string{"podmonitorings"}, nil)
+	if got := pod.Rules[0].Resources; !reflect.DeepEqual(got, []string{"podmonitorings"}) {
+		t.Errorf("podmonitorings webhook Resources = %v, want [podmonitorings]", got)
+	}
+
+	svc := o.webhookFor("servicemonitorings.validation", validateServiceMonitoringPath, []string{"servicemonitorings"}, nil)
+	if got := svc.Rules[0].Resources; !reflect.DeepEqual(got, []string{"servicemonitorings"}) {
+		t.Errorf("servicemonitorings webhook Resources = %v, want [servicemonitorings]", got)
+	}
+
+	podMut := o.mutatingWebhookFor("podmonitorings.mutation", mutatePodMonitoringPath, []string{"podmonitorings"}, nil)
+	if got := podMut.Rules[0].Resources; !reflect.DeepEqual(got, []string{"podmonitorings"}) {
+		t.Errorf("podmonitorings mutating webhook Resources = %v, want [podmonitorings]", got)
+	}
+}